@@ -5,11 +5,82 @@ import (
 )
 
 // Opt is an option for a gas estimator
-type Opt int
+type Opt interface {
+	isOpt()
+}
+
+// FlagOpt is an Opt that carries no value
+type FlagOpt int
+
+func (FlagOpt) isOpt() {}
 
 const (
 	// OptForceRefetch forces the estimator to bust a cache if necessary
-	OptForceRefetch Opt = iota
+	OptForceRefetch FlagOpt = iota
+	// OptSkipValidation makes the attempt builder skip its fee and gas limit sanity checks.
+	// DANGEROUS: only for trusted callers re-signing a fee that was already validated upstream, as an invalid fee is
+	// then only caught by the node, if at all. Never set by default.
+	OptSkipValidation
+	// OptBumpTipOnly makes BumpFee bump only the tip cap of a dynamic fee, keeping the original fee cap. Has no effect
+	// on legacy fees.
+	OptBumpTipOnly
 )
 
+// ExactFeeOpt pins the fee to an exact value, bypassing estimation
+type ExactFeeOpt[FEE Fee] struct {
+	Fee FEE
+}
+
+func (ExactFeeOpt[FEE]) isOpt() {}
+
+// WithExactFee returns an Opt that pins the fee to the given value instead of estimating it,
+// e.g. for replaying historical transactions
+func WithExactFee[FEE Fee](fee FEE) Opt {
+	return ExactFeeOpt[FEE]{Fee: fee}
+}
+
+// ExactFee returns the fee pinned by WithExactFee, if present in opts
+func ExactFee[FEE Fee](opts []Opt) (fee FEE, ok bool) {
+	for _, opt := range opts {
+		if o, isExact := opt.(ExactFeeOpt[FEE]); isExact {
+			return o.Fee, true
+		}
+	}
+	return fee, false
+}
+
+// BaseFeeOpt overrides the base fee a fee-market aware estimator would otherwise fetch from the chain
+type BaseFeeOpt[FEE Fee] struct {
+	BaseFee FEE
+}
+
+func (BaseFeeOpt[FEE]) isOpt() {}
+
+// WithBaseFee returns an Opt that makes the estimator use the given base fee instead of querying the latest one,
+// e.g. for deterministic tests and replaying historical transactions
+func WithBaseFee[FEE Fee](baseFee FEE) Opt {
+	return BaseFeeOpt[FEE]{BaseFee: baseFee}
+}
+
+// BaseFee returns the base fee injected by WithBaseFee, if present in opts
+func BaseFee[FEE Fee](opts []Opt) (baseFee FEE, ok bool) {
+	for _, opt := range opts {
+		if o, isBaseFee := opt.(BaseFeeOpt[FEE]); isBaseFee {
+			return o.BaseFee, true
+		}
+	}
+	return baseFee, false
+}
+
+// HasValueOpt returns true if opts contain an Opt carrying a value, e.g. WithBaseFee, as opposed to only FlagOpts.
+// Such opts change the estimate, so fees estimated with them should not be cached.
+func HasValueOpt(opts []Opt) bool {
+	for _, opt := range opts {
+		if _, isFlag := opt.(FlagOpt); !isFlag {
+			return true
+		}
+	}
+	return false
+}
+
 type Fee fmt.Stringer
@@ -89,6 +89,22 @@ func (_m *Head[BLOCK_HASH, CHAIN_ID]) ChainLength() uint32 {
 	return r0
 }
 
+// ChainTotalDifficulty provides a mock function with given fields:
+func (_m *Head[BLOCK_HASH, CHAIN_ID]) ChainTotalDifficulty() *utils.Big {
+	ret := _m.Called()
+
+	var r0 *utils.Big
+	if rf, ok := ret.Get(0).(func() *utils.Big); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Big)
+		}
+	}
+
+	return r0
+}
+
 // EarliestHeadInChain provides a mock function with given fields:
 func (_m *Head[BLOCK_HASH, CHAIN_ID]) EarliestHeadInChain() types.Head[BLOCK_HASH] {
 	ret := _m.Called()
@@ -177,6 +193,50 @@ func (_m *Head[BLOCK_HASH, CHAIN_ID]) HashAtHeight(blockNum int64) BLOCK_HASH {
 	return r0
 }
 
+// HeadNearestTimestamp provides a mock function with given fields: t
+func (_m *Head[BLOCK_HASH, CHAIN_ID]) HeadNearestTimestamp(t time.Time) types.Head[BLOCK_HASH] {
+	ret := _m.Called(t)
+
+	var r0 types.Head[BLOCK_HASH]
+	if rf, ok := ret.Get(0).(func(time.Time) types.Head[BLOCK_HASH]); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Head[BLOCK_HASH])
+		}
+	}
+
+	return r0
+}
+
+// IsFinalizedAt provides a mock function with given fields: latestBlockNumber, finalityDepth
+func (_m *Head[BLOCK_HASH, CHAIN_ID]) IsFinalizedAt(latestBlockNumber int64, finalityDepth uint32) bool {
+	ret := _m.Called(latestBlockNumber, finalityDepth)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, uint32) bool); ok {
+		r0 = rf(latestBlockNumber, finalityDepth)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsInChain provides a mock function with given fields: blockHash
+func (_m *Head[BLOCK_HASH, CHAIN_ID]) IsInChain(blockHash BLOCK_HASH) bool {
+	ret := _m.Called(blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(BLOCK_HASH) bool); ok {
+		r0 = rf(blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsValid provides a mock function with given fields:
 func (_m *Head[BLOCK_HASH, CHAIN_ID]) IsValid() bool {
 	ret := _m.Called()
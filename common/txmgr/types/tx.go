@@ -2,8 +2,11 @@ package types
 
 import (
 	"context"
+	"encoding"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math/big"
 	"slices"
 	"strings"
@@ -153,6 +156,9 @@ type TxMeta[ADDR types.Hashable, TX_HASH types.Hashable] struct {
 	MessageIDs []string `json:"MessageIDs,omitempty"`
 	// SeqNumbers is used by CCIP for tx to committed sequence numbers correlation in logs
 	SeqNumbers []uint64 `json:"SeqNumbers,omitempty"`
+
+	// Used for rollups - prices the tx with the attempt builder's L1 oracle override, if one is set
+	L1OracleOverride bool `json:"L1OracleOverride,omitempty"`
 }
 
 type TxAttempt[
@@ -175,6 +181,100 @@ type TxAttempt[
 	State                   TxAttemptState
 	Receipts                []ChainReceipt[TX_HASH, BLOCK_HASH] `json:"-"`
 	TxType                  int
+	// FeeCapped is set if the fee reached the max price configured for the sending key, i.e. the estimator
+	// may have wanted to pay more. Not persisted.
+	FeeCapped bool `json:"-"`
+	// IdempotencyKey is copied from the Tx the attempt was built for, empty if the Tx has none. Not persisted.
+	IdempotencyKey string `json:"-"`
+	// Deadline is copied from the Tx the attempt was built for, zero if the Tx has none. Not persisted.
+	Deadline time.Time `json:"-"`
+	// Meta carries the Tx's meta as opaque strings for logs and callbacks, e.g. a job run ID. It is neither signed,
+	// encoded by MarshalBinary nor persisted.
+	Meta map[string]string `json:"-"`
+}
+
+// Clone returns a copy of the attempt that can be mutated without affecting the original.
+// SignedRawTx is copied, and TxFee is deep-copied if FEE has a Clone method.
+func (a TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) Clone() TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE] {
+	if c, ok := any(a.TxFee).(interface{ Clone() FEE }); ok {
+		a.TxFee = c.Clone()
+	}
+	a.SignedRawTx = slices.Clone(a.SignedRawTx)
+	a.Meta = maps.Clone(a.Meta)
+	return a
+}
+
+// TxAttemptEncodingVersion is the version byte written by TxAttempt.MarshalBinary
+const TxAttemptEncodingVersion byte = 1
+
+// MarshalBinary encodes the fields needed to broadcast the attempt from another process: TxID, TxType,
+// ChainSpecificFeeLimit, Hash, TxFee and SignedRawTx, prefixed with TxAttemptEncodingVersion.
+// The encoding is deterministic. FEE must implement encoding.BinaryMarshaler.
+func (a TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) MarshalBinary() ([]byte, error) {
+	m, ok := any(a.TxFee).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.Errorf("cannot marshal attempt: fee type %T does not implement encoding.BinaryMarshaler", a.TxFee)
+	}
+	fee, err := m.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal attempt fee")
+	}
+	b := []byte{TxAttemptEncodingVersion}
+	b = binary.BigEndian.AppendUint64(b, uint64(a.TxID))
+	b = binary.BigEndian.AppendUint64(b, uint64(a.TxType))
+	b = binary.BigEndian.AppendUint32(b, a.ChainSpecificFeeLimit)
+	for _, field := range [][]byte{a.Hash.Bytes(), fee, a.SignedRawTx} {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(field)))
+		b = append(b, field...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes an attempt encoded by MarshalBinary, setting only the encoded fields.
+// Encodings with a version newer than TxAttemptEncodingVersion are rejected rather than misread.
+// *TX_HASH must have a SetBytes method and *FEE must implement encoding.BinaryUnmarshaler.
+func (a *TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("cannot unmarshal attempt: empty data")
+	}
+	if v := data[0]; v != TxAttemptEncodingVersion {
+		return errors.Errorf("cannot unmarshal attempt: unsupported encoding version %d, expected %d", v, TxAttemptEncodingVersion)
+	}
+	data = data[1:]
+	if len(data) < 20 {
+		return errors.New("cannot unmarshal attempt: data too short")
+	}
+	txID, txType, feeLimit := int64(binary.BigEndian.Uint64(data)), int(binary.BigEndian.Uint64(data[8:])), binary.BigEndian.Uint32(data[16:])
+	data = data[20:]
+	fields := make([][]byte, 3)
+	for i := range fields {
+		if len(data) < 4 || uint64(len(data)-4) < uint64(binary.BigEndian.Uint32(data)) {
+			return errors.New("cannot unmarshal attempt: data too short")
+		}
+		n := binary.BigEndian.Uint32(data)
+		fields[i], data = data[4:4+n], data[4+n:]
+	}
+	if len(data) != 0 {
+		return errors.Errorf("cannot unmarshal attempt: %d trailing bytes", len(data))
+	}
+	h, ok := any(&a.Hash).(interface{ SetBytes([]byte) })
+	if !ok {
+		return errors.Errorf("cannot unmarshal attempt: hash type %T does not have a SetBytes method", a.Hash)
+	}
+	u, ok := any(&a.TxFee).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.Errorf("cannot unmarshal attempt: fee type %T does not implement encoding.BinaryUnmarshaler", a.TxFee)
+	}
+	if err := u.UnmarshalBinary(fields[1]); err != nil {
+		return errors.Wrap(err, "cannot unmarshal attempt fee")
+	}
+	h.SetBytes(fields[0])
+	a.TxID, a.TxType, a.ChainSpecificFeeLimit = txID, txType, feeLimit
+	a.SignedRawTx = nil
+	if len(fields[2]) > 0 {
+		a.SignedRawTx = slices.Clone(fields[2])
+	}
+	return nil
 }
 
 func (a *TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) String() string {
@@ -225,6 +325,10 @@ type Tx[
 	SignalCallback bool
 	// Marks tx callback as signaled
 	CallbackCompleted bool
+
+	// Deadline is the wall-clock time by which the tx must be mined, after which the confirmer may give up on it.
+	// Zero means no deadline. Not persisted.
+	Deadline time.Time
 }
 
 func (e *Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) GetError() error {
@@ -234,6 +338,12 @@ func (e *Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) GetError() error {
 	return nil
 }
 
+// ValueBigInt returns a copy of the value transferred by the tx, so callers cannot mutate the tx through it.
+// A zero value Tx returns 0.
+func (e *Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) ValueBigInt() *big.Int {
+	return new(big.Int).Set(&e.Value)
+}
+
 // GetID allows Tx to be used as jsonapi.MarshalIdentifier
 func (e *Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, SEQ, FEE]) GetID() string {
 	return fmt.Sprintf("%d", e.ID)
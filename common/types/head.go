@@ -30,6 +30,13 @@ type Head[BLOCK_HASH Hashable] interface {
 	BlockHash() BLOCK_HASH
 	GetParentHash() BLOCK_HASH
 
+	// IsInChain returns true if the given hash matches the hash of a head in the chain
+	IsInChain(blockHash BLOCK_HASH) bool
+
+	// HeadNearestTimestamp traverses through parents and returns the head whose timestamp is closest to t,
+	// preferring the more recent head on a tie, or nil if the chain is empty
+	HeadNearestTimestamp(t time.Time) Head[BLOCK_HASH]
+
 	// HashAtHeight returns the hash of the block at the given height, if it is in the chain.
 	// If not in chain, returns the zero hash
 	HashAtHeight(blockNum int64) BLOCK_HASH
@@ -37,4 +44,57 @@ type Head[BLOCK_HASH Hashable] interface {
 	// Returns the total difficulty of the block. For chains who do not have a concept of block
 	// difficulty, return 0.
 	BlockDifficulty() *utils.Big
+
+	// ChainTotalDifficulty returns the sum of BlockDifficulty across the chain reachable via GetParent.
+	// For chains who do not have a concept of block difficulty, return 0.
+	ChainTotalDifficulty() *utils.Big
+
+	// IsFinalizedAt returns true if the head is at least finalityDepth blocks
+	// behind the given latest block number
+	IsFinalizedAt(latestBlockNumber int64, finalityDepth uint32) bool
+}
+
+// Distance returns the number of blocks between heads a and b, and whether the lower of the two is an ancestor of
+// (or the same block as) the higher one. Heads at the same height are only related if they are the same block.
+func Distance[BLOCK_HASH Hashable](a, b Head[BLOCK_HASH]) (distance int64, related bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	lower, higher := a, b
+	if lower.BlockNumber() > higher.BlockNumber() {
+		lower, higher = higher, lower
+	}
+	return higher.BlockNumber() - lower.BlockNumber(), higher.IsInChain(lower.BlockHash())
+}
+
+// ReorgDepth returns the number of blocks on oldHead's chain above its latest common ancestor with newHead's chain,
+// i.e. how many blocks were rolled back by switching to newHead. If the chains share no ancestor, the full length of
+// oldHead's chain is returned.
+func ReorgDepth[BLOCK_HASH Hashable](oldHead, newHead Head[BLOCK_HASH]) uint32 {
+	var depth uint32
+	for cur := oldHead; cur != nil; cur = cur.GetParent() {
+		if newHead != nil && newHead.IsInChain(cur.BlockHash()) {
+			return depth
+		}
+		depth++
+	}
+	return depth
+}
+
+// CachedChainLength is optionally implemented by heads that memoize the length of their chain, so hot paths can avoid
+// traversing parents on every call. Implementations must return the same value as ChainLength.
+type CachedChainLength interface {
+	CachedChainLength() uint32
+}
+
+// ChainLengthOf returns the length of h's chain, using the memoized length if h implements CachedChainLength and
+// traversing parents via ChainLength otherwise
+func ChainLengthOf[BLOCK_HASH Hashable](h Head[BLOCK_HASH]) uint32 {
+	if h == nil {
+		return 0
+	}
+	if c, ok := h.(CachedChainLength); ok {
+		return c.CachedChainLength()
+	}
+	return h.ChainLength()
 }
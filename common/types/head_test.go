@@ -0,0 +1,104 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+// forkHashAt produces hashes distinct from hashAt, for building a chain that diverges from one built with hashAt
+func forkHashAt(blockNum int64) common.Hash {
+	return common.BigToHash(big.NewInt(blockNum + 2000))
+}
+
+func TestDistance(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	head := types.NewSimpleHeadChain(5, 10, start, 12*time.Second, hashAt)
+	ancestor := head.Parent.Parent.Parent
+
+	t.Run("ancestor", func(t *testing.T) {
+		distance, related := types.Distance[common.Hash](ancestor, head)
+		assert.Equal(t, int64(3), distance)
+		assert.True(t, related)
+
+		distance, related = types.Distance[common.Hash](head, ancestor)
+		assert.Equal(t, int64(3), distance)
+		assert.True(t, related)
+	})
+	t.Run("non-ancestor", func(t *testing.T) {
+		fork := types.NewSimpleHeadChain(3, 11, start, 12*time.Second, forkHashAt)
+		distance, related := types.Distance[common.Hash](fork, head)
+		assert.Equal(t, int64(1), distance)
+		assert.False(t, related)
+	})
+	t.Run("equal", func(t *testing.T) {
+		distance, related := types.Distance[common.Hash](head, head)
+		assert.Equal(t, int64(0), distance)
+		assert.True(t, related)
+
+		sibling := &types.SimpleHead[common.Hash]{Number: head.Number, Hash: forkHashAt(head.Number), Parent: head.Parent}
+		distance, related = types.Distance[common.Hash](sibling, head)
+		assert.Equal(t, int64(0), distance)
+		assert.False(t, related)
+	})
+	t.Run("nil", func(t *testing.T) {
+		_, related := types.Distance[common.Hash](nil, head)
+		assert.False(t, related)
+	})
+}
+
+func TestReorgDepth(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	// blocks 10 through 14
+	oldHead := types.NewSimpleHeadChain(5, 10, start, 12*time.Second, hashAt)
+
+	t.Run("overlapping chains", func(t *testing.T) {
+		// a fork off block 11 that replaces blocks 12 through 14
+		forkPoint := oldHead.Parent.Parent.Parent
+		newHead := &types.SimpleHead[common.Hash]{Number: 12, Hash: forkHashAt(12), ParentHash: forkPoint.Hash, Parent: forkPoint}
+		newHead = &types.SimpleHead[common.Hash]{Number: 13, Hash: forkHashAt(13), ParentHash: newHead.Hash, Parent: newHead}
+		assert.Equal(t, uint32(3), types.ReorgDepth[common.Hash](oldHead, newHead))
+	})
+	t.Run("new head extends the old one", func(t *testing.T) {
+		newHead := &types.SimpleHead[common.Hash]{Number: 15, Hash: hashAt(15), ParentHash: oldHead.Hash, Parent: oldHead}
+		assert.Equal(t, uint32(0), types.ReorgDepth[common.Hash](oldHead, newHead))
+	})
+	t.Run("fully divergent chains", func(t *testing.T) {
+		newHead := types.NewSimpleHeadChain(6, 10, start, 12*time.Second, forkHashAt)
+		assert.Equal(t, uint32(5), types.ReorgDepth[common.Hash](oldHead, newHead))
+	})
+}
+
+// cachedHead memoizes the chain length of the wrapped head, counting how often it traverses the chain
+type cachedHead struct {
+	*types.SimpleHead[common.Hash]
+	length     uint32
+	traversals int
+}
+
+func (h *cachedHead) CachedChainLength() uint32 {
+	if h.length == 0 {
+		h.traversals++
+		h.length = h.ChainLength()
+	}
+	return h.length
+}
+
+func TestChainLengthOf(t *testing.T) {
+	head := types.NewSimpleHeadChain(5, 10, time.Unix(1700000000, 0), 12*time.Second, hashAt)
+	cached := &cachedHead{SimpleHead: head}
+
+	uncachedLength := types.ChainLengthOf[common.Hash](head)
+	assert.Equal(t, uint32(5), uncachedLength)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, uncachedLength, types.ChainLengthOf[common.Hash](cached))
+	}
+	assert.Equal(t, 1, cached.traversals, "the chain should only be traversed once")
+
+	assert.Equal(t, uint32(0), types.ChainLengthOf[common.Hash](nil))
+}
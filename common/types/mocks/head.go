@@ -75,6 +75,22 @@ func (_m *Head[BLOCK_HASH]) ChainLength() uint32 {
 	return r0
 }
 
+// ChainTotalDifficulty provides a mock function with given fields:
+func (_m *Head[BLOCK_HASH]) ChainTotalDifficulty() *utils.Big {
+	ret := _m.Called()
+
+	var r0 *utils.Big
+	if rf, ok := ret.Get(0).(func() *utils.Big); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Big)
+		}
+	}
+
+	return r0
+}
+
 // EarliestHeadInChain provides a mock function with given fields:
 func (_m *Head[BLOCK_HASH]) EarliestHeadInChain() types.Head[BLOCK_HASH] {
 	ret := _m.Called()
@@ -149,6 +165,50 @@ func (_m *Head[BLOCK_HASH]) HashAtHeight(blockNum int64) BLOCK_HASH {
 	return r0
 }
 
+// HeadNearestTimestamp provides a mock function with given fields: t
+func (_m *Head[BLOCK_HASH]) HeadNearestTimestamp(t time.Time) types.Head[BLOCK_HASH] {
+	ret := _m.Called(t)
+
+	var r0 types.Head[BLOCK_HASH]
+	if rf, ok := ret.Get(0).(func(time.Time) types.Head[BLOCK_HASH]); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Head[BLOCK_HASH])
+		}
+	}
+
+	return r0
+}
+
+// IsFinalizedAt provides a mock function with given fields: latestBlockNumber, finalityDepth
+func (_m *Head[BLOCK_HASH]) IsFinalizedAt(latestBlockNumber int64, finalityDepth uint32) bool {
+	ret := _m.Called(latestBlockNumber, finalityDepth)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, uint32) bool); ok {
+		r0 = rf(latestBlockNumber, finalityDepth)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsInChain provides a mock function with given fields: blockHash
+func (_m *Head[BLOCK_HASH]) IsInChain(blockHash BLOCK_HASH) bool {
+	ret := _m.Called(blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(BLOCK_HASH) bool); ok {
+		r0 = rf(blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewHead creates a new instance of Head. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHead[BLOCK_HASH types.Hashable](t interface {
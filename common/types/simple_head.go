@@ -0,0 +1,149 @@
+package types
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// SimpleHead is a minimal in-memory implementation of Head, intended for use in tests
+// that need a chain of heads with real parent links.
+type SimpleHead[BLOCK_HASH Hashable] struct {
+	Number     int64
+	Hash       BLOCK_HASH
+	ParentHash BLOCK_HASH
+	Parent     *SimpleHead[BLOCK_HASH]
+	Timestamp  time.Time
+	Difficulty *utils.Big
+}
+
+// NewSimpleHeadChain builds a chain of n heads with sequential block numbers starting at
+// startNumber, timestamps spaced by blockTime starting at startTime, and hashes produced by
+// hashAt. It returns the latest head, whose parents link back to the earliest one.
+func NewSimpleHeadChain[BLOCK_HASH Hashable](n int, startNumber int64, startTime time.Time, blockTime time.Duration, hashAt func(blockNum int64) BLOCK_HASH) *SimpleHead[BLOCK_HASH] {
+	var head *SimpleHead[BLOCK_HASH]
+	for i := 0; i < n; i++ {
+		num := startNumber + int64(i)
+		h := &SimpleHead[BLOCK_HASH]{
+			Number:     num,
+			Hash:       hashAt(num),
+			Timestamp:  startTime.Add(time.Duration(i) * blockTime),
+			Difficulty: utils.NewBigI(0),
+		}
+		if head != nil {
+			h.Parent = head
+			h.ParentHash = head.Hash
+		}
+		head = h
+	}
+	return head
+}
+
+func (h *SimpleHead[BLOCK_HASH]) BlockNumber() int64 {
+	return h.Number
+}
+
+func (h *SimpleHead[BLOCK_HASH]) GetTimestamp() time.Time {
+	return h.Timestamp
+}
+
+// ChainLength returns the length of the chain followed by recursively looking up parents
+func (h *SimpleHead[BLOCK_HASH]) ChainLength() uint32 {
+	if h == nil {
+		return 0
+	}
+	l := uint32(1)
+	for cur := h; cur.Parent != nil; cur = cur.Parent {
+		l++
+	}
+	return l
+}
+
+// EarliestHeadInChain recurses through parents until it finds the earliest one
+func (h *SimpleHead[BLOCK_HASH]) EarliestHeadInChain() Head[BLOCK_HASH] {
+	cur := h
+	for cur.Parent != nil {
+		cur = cur.Parent
+	}
+	return cur
+}
+
+func (h *SimpleHead[BLOCK_HASH]) GetParent() Head[BLOCK_HASH] {
+	if h.Parent == nil {
+		return nil
+	}
+	return h.Parent
+}
+
+func (h *SimpleHead[BLOCK_HASH]) BlockHash() BLOCK_HASH {
+	return h.Hash
+}
+
+func (h *SimpleHead[BLOCK_HASH]) GetParentHash() BLOCK_HASH {
+	return h.ParentHash
+}
+
+// IsInChain returns true if the given hash matches the hash of a head in the chain
+func (h *SimpleHead[BLOCK_HASH]) IsInChain(blockHash BLOCK_HASH) bool {
+	for cur := h; cur != nil; cur = cur.Parent {
+		if cur.Hash == blockHash {
+			return true
+		}
+	}
+	return false
+}
+
+// HeadNearestTimestamp traverses through parents and returns the head whose timestamp is closest to t,
+// preferring the more recent head on a tie, or nil if the chain is empty
+func (h *SimpleHead[BLOCK_HASH]) HeadNearestTimestamp(t time.Time) Head[BLOCK_HASH] {
+	if h == nil {
+		return nil
+	}
+	nearest, nearestDiff := h, absDuration(t.Sub(h.Timestamp))
+	for cur := h.Parent; cur != nil; cur = cur.Parent {
+		if diff := absDuration(t.Sub(cur.Timestamp)); diff < nearestDiff {
+			nearest, nearestDiff = cur, diff
+		}
+	}
+	return nearest
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// HashAtHeight returns the hash of the block at the given height, if it is in the chain.
+// If not in chain, returns the zero hash
+func (h *SimpleHead[BLOCK_HASH]) HashAtHeight(blockNum int64) BLOCK_HASH {
+	for cur := h; cur != nil; cur = cur.Parent {
+		if cur.Number == blockNum {
+			return cur.Hash
+		}
+	}
+	var zero BLOCK_HASH
+	return zero
+}
+
+func (h *SimpleHead[BLOCK_HASH]) BlockDifficulty() *utils.Big {
+	return h.Difficulty
+}
+
+// ChainTotalDifficulty returns the sum of block difficulties across the chain followed by recursively looking up parents
+func (h *SimpleHead[BLOCK_HASH]) ChainTotalDifficulty() *utils.Big {
+	total := utils.NewBigI(0)
+	for cur := h; cur != nil; cur = cur.Parent {
+		if cur.Difficulty != nil {
+			total = total.Add(cur.Difficulty)
+		}
+	}
+	return total
+}
+
+// IsFinalizedAt returns true if the head is at least finalityDepth blocks
+// behind the given latest block number
+func (h *SimpleHead[BLOCK_HASH]) IsFinalizedAt(latestBlockNumber int64, finalityDepth uint32) bool {
+	return latestBlockNumber-h.Number >= int64(finalityDepth)
+}
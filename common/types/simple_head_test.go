@@ -0,0 +1,90 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+var _ types.Head[common.Hash] = (*types.SimpleHead[common.Hash])(nil)
+
+func hashAt(blockNum int64) common.Hash {
+	return common.BigToHash(big.NewInt(blockNum + 1000))
+}
+
+func TestSimpleHead_NewSimpleHeadChain(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	head := types.NewSimpleHeadChain(3, 10, start, 12*time.Second, hashAt)
+
+	require.NotNil(t, head)
+	assert.Equal(t, int64(12), head.BlockNumber())
+	assert.Equal(t, hashAt(12), head.BlockHash())
+	assert.Equal(t, hashAt(11), head.GetParentHash())
+	assert.Equal(t, start.Add(24*time.Second), head.GetTimestamp())
+	assert.Equal(t, uint32(3), head.ChainLength())
+
+	parent := head.GetParent()
+	require.NotNil(t, parent)
+	assert.Equal(t, int64(11), parent.BlockNumber())
+	assert.Equal(t, hashAt(11), parent.BlockHash())
+	assert.Equal(t, start.Add(12*time.Second), parent.GetTimestamp())
+	assert.Equal(t, uint32(2), parent.ChainLength())
+
+	earliest := head.EarliestHeadInChain()
+	assert.Equal(t, int64(10), earliest.BlockNumber())
+	assert.Equal(t, common.Hash{}, earliest.GetParentHash())
+	assert.Nil(t, earliest.GetParent())
+	assert.Equal(t, uint32(1), earliest.ChainLength())
+
+	assert.Equal(t, hashAt(10), head.HashAtHeight(10))
+	assert.Equal(t, hashAt(11), head.HashAtHeight(11))
+	assert.Equal(t, common.Hash{}, head.HashAtHeight(9))
+	assert.Equal(t, common.Hash{}, head.HashAtHeight(13))
+	assert.Equal(t, int64(0), head.BlockDifficulty().Int64())
+
+	var nilHead *types.SimpleHead[common.Hash]
+	assert.Equal(t, uint32(0), nilHead.ChainLength())
+}
+
+func TestSimpleHead_IsInChain(t *testing.T) {
+	head := types.NewSimpleHeadChain(3, 10, time.Unix(1700000000, 0), 12*time.Second, hashAt)
+
+	assert.True(t, head.IsInChain(hashAt(12)))
+	assert.True(t, head.IsInChain(hashAt(10)))
+	assert.False(t, head.IsInChain(hashAt(9)))
+	assert.False(t, head.IsInChain(hashAt(13)))
+	assert.False(t, head.GetParent().IsInChain(hashAt(12)), "descendants are not in a head's chain")
+}
+
+func TestSimpleHead_HeadNearestTimestamp(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	// blocks 10, 11, 12 at start, start+12s and start+24s
+	head := types.NewSimpleHeadChain(3, 10, start, 12*time.Second, hashAt)
+
+	assert.Equal(t, int64(10), head.HeadNearestTimestamp(start.Add(-time.Hour)).BlockNumber())
+	assert.Equal(t, int64(10), head.HeadNearestTimestamp(start.Add(5*time.Second)).BlockNumber())
+	assert.Equal(t, int64(11), head.HeadNearestTimestamp(start.Add(13*time.Second)).BlockNumber())
+	assert.Equal(t, int64(12), head.HeadNearestTimestamp(start.Add(18*time.Second)).BlockNumber(), "ties go to the more recent head")
+	assert.Equal(t, int64(12), head.HeadNearestTimestamp(start.Add(time.Hour)).BlockNumber())
+
+	var nilHead *types.SimpleHead[common.Hash]
+	assert.Nil(t, nilHead.HeadNearestTimestamp(start))
+}
+
+func TestSimpleHead_ChainTotalDifficulty(t *testing.T) {
+	head := types.NewSimpleHeadChain(3, 1, time.Unix(1700000000, 0), 12*time.Second, hashAt)
+	assert.Equal(t, int64(0), head.ChainTotalDifficulty().Int64())
+
+	head.Difficulty = utils.NewBigI(7)
+	head.Parent.Difficulty = utils.NewBigI(5)
+	head.Parent.Parent.Difficulty = utils.NewBigI(3)
+	assert.Equal(t, int64(15), head.ChainTotalDifficulty().Int64())
+	assert.Equal(t, int64(8), head.Parent.ChainTotalDifficulty().Int64())
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
@@ -100,6 +102,102 @@ func (g *gasEstimatorConfig) TipCapMin() *assets.Wei {
 	return g.c.TipCapMin
 }
 
+func (g *gasEstimatorConfig) MinBumpPercent() uint16 {
+	if g.c.MinBumpPercent == nil {
+		return 0
+	}
+	return *g.c.MinBumpPercent
+}
+
+func (g *gasEstimatorConfig) TipOnlyDynamicFees() bool {
+	if g.c.TipOnlyDynamicFees == nil {
+		return false
+	}
+	return *g.c.TipOnlyDynamicFees
+}
+
+func (g *gasEstimatorConfig) TipOnlyBaseFeeMultiplier() uint16 {
+	if g.c.TipOnlyBaseFeeMultiplier == nil {
+		return 0
+	}
+	return *g.c.TipOnlyBaseFeeMultiplier
+}
+
+func (g *gasEstimatorConfig) MandatoryBaseFee() *assets.Wei {
+	return g.c.MandatoryBaseFee
+}
+
+func (g *gasEstimatorConfig) MaxPayloadBytes() uint32 {
+	if g.c.MaxPayloadBytes == nil {
+		return 0
+	}
+	return *g.c.MaxPayloadBytes
+}
+
+func (g *gasEstimatorConfig) UpgradeLegacyOnBump() bool {
+	if g.c.UpgradeLegacyOnBump == nil {
+		return false
+	}
+	return *g.c.UpgradeLegacyOnBump
+}
+
+func (g *gasEstimatorConfig) BumpTipOnly() bool {
+	if g.c.BumpTipOnly == nil {
+		return false
+	}
+	return *g.c.BumpTipOnly
+}
+
+func (g *gasEstimatorConfig) MaxPriorAttempts() uint32 {
+	if g.c.MaxPriorAttempts == nil {
+		return 0
+	}
+	return *g.c.MaxPriorAttempts
+}
+
+func (g *gasEstimatorConfig) MinFeeCapTipMarginWei() *assets.Wei {
+	return g.c.MinFeeCapTipMargin
+}
+
+func (g *gasEstimatorConfig) BumpGasLimitPercent() uint16 {
+	if g.c.BumpGasLimitPercent == nil {
+		return 0
+	}
+	return *g.c.BumpGasLimitPercent
+}
+
+func (g *gasEstimatorConfig) BumpGasLimitMax() uint32 {
+	if g.c.BumpGasLimitMax == nil {
+		return 0
+	}
+	return *g.c.BumpGasLimitMax
+}
+
+func (g *gasEstimatorConfig) MaxTotalCostWei() *assets.Wei {
+	return g.c.MaxTotalCost
+}
+
+func (g *gasEstimatorConfig) BumpJitterBasisPoints() uint16 {
+	if g.c.BumpJitterBasisPoints == nil {
+		return 0
+	}
+	return *g.c.BumpJitterBasisPoints
+}
+
+func (g *gasEstimatorConfig) StripAccessListOnBump() bool {
+	if g.c.StripAccessListOnBump == nil {
+		return false
+	}
+	return *g.c.StripAccessListOnBump
+}
+
+func (g *gasEstimatorConfig) StaleFeeGracePeriod() time.Duration {
+	if g.c.StaleFeeGracePeriod == nil {
+		return 0
+	}
+	return g.c.StaleFeeGracePeriod.Duration()
+}
+
 func (g *gasEstimatorConfig) Mode() string {
 	return *g.c.Mode
 }
@@ -169,6 +267,13 @@ func (b *blockHistoryConfig) TransactionPercentile() uint16 {
 	return *b.c.TransactionPercentile
 }
 
+func (b *blockHistoryConfig) FeeSpikeThresholdPercent() uint16 {
+	if b.c.FeeSpikeThresholdPercent == nil {
+		return 0
+	}
+	return *b.c.FeeSpikeThresholdPercent
+}
+
 func (b *blockHistoryConfig) BlockDelay() uint16 {
 	return *b.blockDelay
 }
@@ -101,6 +101,21 @@ type GasEstimator interface {
 	PriceMin() *assets.Wei
 	Mode() string
 	PriceMaxKey(gethcommon.Address) *assets.Wei
+	MinBumpPercent() uint16
+	TipOnlyDynamicFees() bool
+	TipOnlyBaseFeeMultiplier() uint16
+	MandatoryBaseFee() *assets.Wei
+	MaxPayloadBytes() uint32
+	UpgradeLegacyOnBump() bool
+	BumpTipOnly() bool
+	MaxPriorAttempts() uint32
+	MinFeeCapTipMarginWei() *assets.Wei
+	BumpGasLimitPercent() uint16
+	BumpGasLimitMax() uint32
+	MaxTotalCostWei() *assets.Wei
+	BumpJitterBasisPoints() uint16
+	StripAccessListOnBump() bool
+	StaleFeeGracePeriod() time.Duration
 }
 
 type LimitJobType interface {
@@ -120,6 +135,7 @@ type BlockHistory interface {
 	CheckInclusionPercentile() uint16
 	EIP1559FeeCapBufferBlocks() uint16
 	TransactionPercentile() uint16
+	FeeSpikeThresholdPercent() uint16
 }
 
 type NodePool interface {
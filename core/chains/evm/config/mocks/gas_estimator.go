@@ -9,6 +9,8 @@ import (
 	config "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // GasEstimator is an autogenerated mock type for the GasEstimator type
@@ -32,6 +34,48 @@ func (_m *GasEstimator) BlockHistory() config.BlockHistory {
 	return r0
 }
 
+// BumpGasLimitMax provides a mock function with given fields:
+func (_m *GasEstimator) BumpGasLimitMax() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// BumpGasLimitPercent provides a mock function with given fields:
+func (_m *GasEstimator) BumpGasLimitPercent() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// BumpJitterBasisPoints provides a mock function with given fields:
+func (_m *GasEstimator) BumpJitterBasisPoints() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
 // BumpMin provides a mock function with given fields:
 func (_m *GasEstimator) BumpMin() *assets.Wei {
 	ret := _m.Called()
@@ -76,6 +120,20 @@ func (_m *GasEstimator) BumpThreshold() uint64 {
 	return r0
 }
 
+// BumpTipOnly provides a mock function with given fields:
+func (_m *GasEstimator) BumpTipOnly() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // BumpTxDepth provides a mock function with given fields:
 func (_m *GasEstimator) BumpTxDepth() uint32 {
 	ret := _m.Called()
@@ -192,6 +250,96 @@ func (_m *GasEstimator) LimitTransfer() uint32 {
 	return r0
 }
 
+// MandatoryBaseFee provides a mock function with given fields:
+func (_m *GasEstimator) MandatoryBaseFee() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// MaxPayloadBytes provides a mock function with given fields:
+func (_m *GasEstimator) MaxPayloadBytes() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// MaxPriorAttempts provides a mock function with given fields:
+func (_m *GasEstimator) MaxPriorAttempts() uint32 {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
+// MaxTotalCostWei provides a mock function with given fields:
+func (_m *GasEstimator) MaxTotalCostWei() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
+// MinBumpPercent provides a mock function with given fields:
+func (_m *GasEstimator) MinBumpPercent() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// MinFeeCapTipMarginWei provides a mock function with given fields:
+func (_m *GasEstimator) MinFeeCapTipMarginWei() *assets.Wei {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	return r0
+}
+
 // Mode provides a mock function with given fields:
 func (_m *GasEstimator) Mode() string {
 	ret := _m.Called()
@@ -270,6 +418,34 @@ func (_m *GasEstimator) PriceMin() *assets.Wei {
 	return r0
 }
 
+// StaleFeeGracePeriod provides a mock function with given fields:
+func (_m *GasEstimator) StaleFeeGracePeriod() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// StripAccessListOnBump provides a mock function with given fields:
+func (_m *GasEstimator) StripAccessListOnBump() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // TipCapDefault provides a mock function with given fields:
 func (_m *GasEstimator) TipCapDefault() *assets.Wei {
 	ret := _m.Called()
@@ -302,6 +478,48 @@ func (_m *GasEstimator) TipCapMin() *assets.Wei {
 	return r0
 }
 
+// TipOnlyBaseFeeMultiplier provides a mock function with given fields:
+func (_m *GasEstimator) TipOnlyBaseFeeMultiplier() uint16 {
+	ret := _m.Called()
+
+	var r0 uint16
+	if rf, ok := ret.Get(0).(func() uint16); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint16)
+	}
+
+	return r0
+}
+
+// TipOnlyDynamicFees provides a mock function with given fields:
+func (_m *GasEstimator) TipOnlyDynamicFees() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// UpgradeLegacyOnBump provides a mock function with given fields:
+func (_m *GasEstimator) UpgradeLegacyOnBump() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewGasEstimator creates a new instance of GasEstimator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGasEstimator(t interface {
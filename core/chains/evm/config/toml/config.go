@@ -479,13 +479,31 @@ type GasEstimator struct {
 
 	EIP1559DynamicFees *bool
 
-	FeeCapDefault *assets.Wei
-	TipCapDefault *assets.Wei
-	TipCapMin     *assets.Wei
+	FeeCapDefault            *assets.Wei
+	TipCapDefault            *assets.Wei
+	TipCapMin                *assets.Wei
+	MinBumpPercent           *uint16
+	TipOnlyDynamicFees       *bool
+	TipOnlyBaseFeeMultiplier *uint16
+	MandatoryBaseFee         *assets.Wei
+	MaxPayloadBytes          *uint32
+	UpgradeLegacyOnBump      *bool
+	BumpTipOnly              *bool
+	MaxPriorAttempts         *uint32
+	MinFeeCapTipMargin       *assets.Wei
+	BumpGasLimitPercent      *uint16
+	BumpGasLimitMax          *uint32
+	MaxTotalCost             *assets.Wei
+	BumpJitterBasisPoints    *uint16
+	StripAccessListOnBump    *bool
+	StaleFeeGracePeriod      *models.Duration
 
 	BlockHistory BlockHistoryEstimator `toml:",omitempty"`
 }
 
+// maxBumpJitterBasisPoints limits BumpJitterBasisPoints to 10% either way, which is plenty to spread keys apart.
+const maxBumpJitterBasisPoints = 1_000
+
 func (e *GasEstimator) ValidateConfig() (err error) {
 	if uint64(*e.BumpPercent) < txpool.DefaultConfig.PriceBump {
 		err = multierr.Append(err, configutils.ErrInvalid{Name: "BumpPercent", Value: *e.BumpPercent,
@@ -516,6 +534,10 @@ func (e *GasEstimator) ValidateConfig() (err error) {
 		err = multierr.Append(err, configutils.ErrInvalid{Name: "PriceMax", Value: e.PriceMin,
 			Msg: "must be greater than or equal to PriceDefault"})
 	}
+	if e.BumpJitterBasisPoints != nil && *e.BumpJitterBasisPoints > maxBumpJitterBasisPoints {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "BumpJitterBasisPoints", Value: *e.BumpJitterBasisPoints,
+			Msg: fmt.Sprintf("must be less than or equal to %d", maxBumpJitterBasisPoints)})
+	}
 	if *e.Mode == "BlockHistory" && *e.BlockHistory.BlockHistorySize <= 0 {
 		err = multierr.Append(err, configutils.ErrInvalid{Name: "BlockHistory.BlockHistorySize", Value: *e.BlockHistory.BlockHistorySize,
 			Msg: "must be greater than or equal to 1 with BlockHistory Mode"})
@@ -573,6 +595,51 @@ func (e *GasEstimator) setFrom(f *GasEstimator) {
 	if v := f.PriceMin; v != nil {
 		e.PriceMin = v
 	}
+	if v := f.MinBumpPercent; v != nil {
+		e.MinBumpPercent = v
+	}
+	if v := f.TipOnlyDynamicFees; v != nil {
+		e.TipOnlyDynamicFees = v
+	}
+	if v := f.TipOnlyBaseFeeMultiplier; v != nil {
+		e.TipOnlyBaseFeeMultiplier = v
+	}
+	if v := f.MandatoryBaseFee; v != nil {
+		e.MandatoryBaseFee = v
+	}
+	if v := f.MaxPayloadBytes; v != nil {
+		e.MaxPayloadBytes = v
+	}
+	if v := f.UpgradeLegacyOnBump; v != nil {
+		e.UpgradeLegacyOnBump = v
+	}
+	if v := f.BumpTipOnly; v != nil {
+		e.BumpTipOnly = v
+	}
+	if v := f.MaxPriorAttempts; v != nil {
+		e.MaxPriorAttempts = v
+	}
+	if v := f.MinFeeCapTipMargin; v != nil {
+		e.MinFeeCapTipMargin = v
+	}
+	if v := f.BumpGasLimitPercent; v != nil {
+		e.BumpGasLimitPercent = v
+	}
+	if v := f.BumpGasLimitMax; v != nil {
+		e.BumpGasLimitMax = v
+	}
+	if v := f.MaxTotalCost; v != nil {
+		e.MaxTotalCost = v
+	}
+	if v := f.BumpJitterBasisPoints; v != nil {
+		e.BumpJitterBasisPoints = v
+	}
+	if v := f.StripAccessListOnBump; v != nil {
+		e.StripAccessListOnBump = v
+	}
+	if v := f.StaleFeeGracePeriod; v != nil {
+		e.StaleFeeGracePeriod = v
+	}
 	e.LimitJobType.setFrom(&f.LimitJobType)
 	e.BlockHistory.setFrom(&f.BlockHistory)
 }
@@ -614,6 +681,7 @@ type BlockHistoryEstimator struct {
 	CheckInclusionPercentile  *uint16
 	EIP1559FeeCapBufferBlocks *uint16
 	TransactionPercentile     *uint16
+	FeeSpikeThresholdPercent  *uint16
 }
 
 func (e *BlockHistoryEstimator) setFrom(f *BlockHistoryEstimator) {
@@ -635,6 +703,9 @@ func (e *BlockHistoryEstimator) setFrom(f *BlockHistoryEstimator) {
 	if v := f.TransactionPercentile; v != nil {
 		e.TransactionPercentile = v
 	}
+	if v := f.FeeSpikeThresholdPercent; v != nil {
+		e.FeeSpikeThresholdPercent = v
+	}
 }
 
 type KeySpecificConfig []KeySpecific
@@ -109,6 +109,14 @@ func (a *arbitrumEstimator) GetLegacyGas(ctx context.Context, calldata []byte, l
 		return
 	}
 	gasPrice = a.gasPriceWithBuffer(gasPrice, maxGasPriceWei)
+	if chainSpecificGasLimit, err = a.EstimateGasLimit(ctx, calldata, l2GasLimit, opts...); err != nil {
+		return nil, 0, err
+	}
+	return
+}
+
+// EstimateGasLimit computes the gas limit as described for GetLegacyGas, without estimating a price
+func (a *arbitrumEstimator) EstimateGasLimit(ctx context.Context, calldata []byte, l2GasLimit uint32, opts ...feetypes.Opt) (chainSpecificGasLimit uint32, err error) {
 	ok := a.IfStarted(func() {
 		if slices.Contains(opts, feetypes.OptForceRefetch) {
 			ch := make(chan struct{})
@@ -133,11 +141,11 @@ func (a *arbitrumEstimator) GetLegacyGas(ctx context.Context, calldata []byte, l
 		}
 		perL2Tx, perL1CalldataUnit := a.getPricesInArbGas()
 		chainSpecificGasLimit = l2GasLimit + perL2Tx + uint32(len(calldata))*perL1CalldataUnit
-		a.logger.Debugw("GetLegacyGas", "l2GasLimit", l2GasLimit, "calldataLen", len(calldata), "perL2Tx", perL2Tx,
+		a.logger.Debugw("EstimateGasLimit", "l2GasLimit", l2GasLimit, "calldataLen", len(calldata), "perL2Tx", perL2Tx,
 			"perL1CalldataUnit", perL1CalldataUnit, "chainSpecificGasLimit", chainSpecificGasLimit)
 	})
 	if !ok {
-		return nil, 0, errors.New("estimator is not started")
+		return 0, errors.New("estimator is not started")
 	} else if err != nil {
 		return
 	}
@@ -72,6 +72,12 @@ var (
 	},
 		[]string{"evmChainID", "mode"},
 	)
+	promBlockHistoryEstimatorFeeSpikeCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "block_history_estimator_fee_spike_count",
+		Help: "Counter is incremented every time the base fee increases by more than the configured fee spike percentage between consecutive heads",
+	},
+		[]string{"evmChainID"},
+	)
 )
 
 const BumpingHaltedLabel = "Tx gas bumping halted since price exceeds current block prices by significant margin; tx will continue to be rebroadcasted but your node, RPC, or the chain might be experiencing connectivity issues; please investigate and fix ASAP"
@@ -120,8 +126,18 @@ type (
 		latestMu     sync.RWMutex
 		initialFetch atomic.Bool
 
+		feeSpikePercent uint16
+		onFeeSpike      func(FeeSpikeEvent)
+
 		logger logger.SugaredLogger
 	}
+
+	// FeeSpikeEvent describes a sharp base fee increase between consecutive heads
+	FeeSpikeEvent struct {
+		BlockNumber     int64
+		PreviousBaseFee *assets.Wei
+		CurrentBaseFee  *assets.Wei
+	}
 )
 
 // NewBlockHistoryEstimator returns a new BlockHistoryEstimator that listens
@@ -143,20 +159,79 @@ func NewBlockHistoryEstimator(lggr logger.Logger, ethClient evmclient.Client, cf
 		ctx:       ctx,
 		ctxCancel: cancel,
 		logger:    logger.Sugared(lggr.Named("BlockHistoryEstimator")),
+
+		feeSpikePercent: bhCfg.FeeSpikeThresholdPercent(),
 	}
 
 	return b
 }
 
+// SetFeeSpikeHandler registers a callback that is invoked whenever the base fee
+// increases by more than FeeSpikeThresholdPercent between consecutive heads, e.g. so
+// that downstream services can pause non-urgent transactions.
+// It must be called before the estimator is started.
+func (b *BlockHistoryEstimator) SetFeeSpikeHandler(fn func(FeeSpikeEvent)) {
+	b.onFeeSpike = fn
+}
+
 // OnNewLongestChain recalculates and sets global gas price if a sampled new head comes
 // in and we are not currently fetching
 func (b *BlockHistoryEstimator) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	b.checkFeeSpike(head)
 	// set latest base fee here to avoid potential lag introduced by block delay
 	// it is really important that base fee be as up-to-date as possible
 	b.setLatest(head)
 	b.mb.Deliver(head)
 }
 
+// OnReorg drops blocks above the common ancestor from the history, since they are no longer in the canonical chain.
+// The history is cleared entirely if the common ancestor is unknown.
+func (b *BlockHistoryEstimator) OnReorg(_ context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head) {
+	b.blocksMu.Lock()
+	var kept []evmtypes.Block
+	if commonAncestor != nil {
+		for _, block := range b.blocks {
+			if block.Number <= commonAncestor.Number {
+				kept = append(kept, block)
+			}
+		}
+	}
+	dropped := len(b.blocks) - len(kept)
+	b.blocks = kept
+	b.blocksMu.Unlock()
+	b.logger.Debugw("Reorg detected, dropped orphaned blocks from history", "headNum", head.Number, "dropped", dropped)
+}
+
+// checkFeeSpike compares the base fee of head against the latest head's base fee
+// and reports a fee spike if it increased by more than the configured percentage.
+// Detection is disabled if FeeSpikeThresholdPercent is zero.
+func (b *BlockHistoryEstimator) checkFeeSpike(head *evmtypes.Head) {
+	if b.feeSpikePercent == 0 || head.BaseFeePerGas == nil {
+		return
+	}
+	b.latestMu.RLock()
+	prev := b.latest
+	b.latestMu.RUnlock()
+	// Non-eip1559 blocks don't include base fee
+	if prev == nil || prev.BaseFeePerGas == nil || prev.BaseFeePerGas.IsZero() {
+		return
+	}
+	if head.BaseFeePerGas.Cmp(prev.BaseFeePerGas.AddPercentage(b.feeSpikePercent)) <= 0 {
+		return
+	}
+	b.logger.Warnw(fmt.Sprintf("Base fee spiked by more than %d%% between consecutive heads", b.feeSpikePercent),
+		"blockNum", head.Number, "previousBaseFee", prev.BaseFeePerGas, "currentBaseFee", head.BaseFeePerGas)
+	promBlockHistoryEstimatorFeeSpikeCount.WithLabelValues(b.chainID.String()).Inc()
+	if b.onFeeSpike == nil {
+		return
+	}
+	b.onFeeSpike(FeeSpikeEvent{
+		BlockNumber:     head.Number,
+		PreviousBaseFee: prev.BaseFeePerGas,
+		CurrentBaseFee:  head.BaseFeePerGas,
+	})
+}
+
 // setLatest assumes that head won't be mutated
 func (b *BlockHistoryEstimator) setLatest(head *evmtypes.Head) {
 	// Non-eip1559 blocks don't include base fee
@@ -169,6 +244,14 @@ func (b *BlockHistoryEstimator) setLatest(head *evmtypes.Head) {
 	b.latest = head
 }
 
+// baseFee returns the base fee injected with WithBaseFee, falling back to the latest head's base fee
+func (b *BlockHistoryEstimator) baseFee(opts []feetypes.Opt) *assets.Wei {
+	if baseFee, ok := feetypes.BaseFee[*assets.Wei](opts); ok {
+		return baseFee
+	}
+	return b.getCurrentBaseFee()
+}
+
 func (b *BlockHistoryEstimator) getCurrentBaseFee() *assets.Wei {
 	b.latestMu.RLock()
 	defer b.latestMu.RUnlock()
@@ -178,6 +261,18 @@ func (b *BlockHistoryEstimator) getCurrentBaseFee() *assets.Wei {
 	return b.latest.BaseFeePerGas
 }
 
+// CurrentFees returns the base fee of the latest head and the current tip cap estimate, or ok=false before the
+// first head has been seen
+func (b *BlockHistoryEstimator) CurrentFees() (baseFee *assets.Wei, suggestedTip *assets.Wei, ok bool) {
+	b.latestMu.RLock()
+	latest := b.latest
+	b.latestMu.RUnlock()
+	if latest == nil {
+		return nil, nil, false
+	}
+	return latest.BaseFeePerGas, b.getTipCap(), true
+}
+
 func (b *BlockHistoryEstimator) getCurrentBlockNum() *int64 {
 	b.latestMu.RLock()
 	defer b.latestMu.RUnlock()
@@ -267,6 +362,10 @@ func (b *BlockHistoryEstimator) GetLegacyGas(_ context.Context, _ []byte, gasLim
 	return
 }
 
+func (b *BlockHistoryEstimator) EstimateGasLimit(_ context.Context, _ []byte, gasLimit uint32, _ ...feetypes.Opt) (uint32, error) {
+	return commonfee.ApplyMultiplier(gasLimit, b.eConfig.LimitMultiplier())
+}
+
 func (b *BlockHistoryEstimator) getGasPrice() *assets.Wei {
 	b.priceMu.RLock()
 	defer b.priceMu.RUnlock()
@@ -389,7 +488,7 @@ func (b *BlockHistoryEstimator) checkConnectivity(attempts []EvmPriorAttempt) er
 	return nil
 }
 
-func (b *BlockHistoryEstimator) GetDynamicFee(_ context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei) (fee DynamicFee, chainSpecificGasLimit uint32, err error) {
+func (b *BlockHistoryEstimator) GetDynamicFee(_ context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei, opts ...feetypes.Opt) (fee DynamicFee, chainSpecificGasLimit uint32, err error) {
 	if !b.eConfig.EIP1559DynamicFees() {
 		return fee, 0, errors.New("Can't get dynamic fee, EIP1559 is disabled")
 	}
@@ -417,12 +516,12 @@ func (b *BlockHistoryEstimator) GetDynamicFee(_ context.Context, gasLimit uint32
 		if b.eConfig.BumpThreshold() == 0 {
 			// just use the max gas price if gas bumping is disabled
 			feeCap = maxGasPrice
-		} else if b.getCurrentBaseFee() != nil {
+		} else if baseFee := b.baseFee(opts); baseFee != nil {
 			// HACK: due to a flaw of how EIP-1559 is implemented we have to
 			// set a much lower FeeCap than the actual maximum we are willing
 			// to pay in order to give ourselves headroom for bumping
 			// See: https://github.com/ethereum/go-ethereum/issues/24284
-			feeCap = calcFeeCap(b.getCurrentBaseFee(), int(b.bhConfig.EIP1559FeeCapBufferBlocks()), tipCap, maxGasPrice)
+			feeCap = calcFeeCap(baseFee, int(b.bhConfig.EIP1559FeeCapBufferBlocks()), tipCap, maxGasPrice)
 		} else {
 			// This shouldn't happen on EIP-1559 blocks, since if the tip cap
 			// is set, Start must have succeeded and we would expect an initial
@@ -462,7 +561,7 @@ func calcFeeCap(latestAvailableBaseFeePerGas *assets.Wei, bufferBlocks int, tipC
 	return feeCap
 }
 
-func (b *BlockHistoryEstimator) BumpDynamicFee(_ context.Context, originalFee DynamicFee, originalGasLimit uint32, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
+func (b *BlockHistoryEstimator) BumpDynamicFee(_ context.Context, originalFee DynamicFee, originalGasLimit uint32, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
 	if b.bhConfig.CheckInclusionBlocks() > 0 {
 		if err = b.checkConnectivity(attempts); err != nil {
 			if errors.Is(err, commonfee.ErrConnectivity) {
@@ -473,7 +572,50 @@ func (b *BlockHistoryEstimator) BumpDynamicFee(_ context.Context, originalFee Dy
 			return bumped, 0, err
 		}
 	}
-	return BumpDynamicFeeOnly(b.eConfig, b.bhConfig.EIP1559FeeCapBufferBlocks(), b.logger, b.getTipCap(), b.getCurrentBaseFee(), originalFee, originalGasLimit, maxGasPriceWei)
+	return BumpDynamicFeeOnly(b.eConfig, b.bhConfig.EIP1559FeeCapBufferBlocks(), b.logger, b.getTipCap(), b.baseFee(opts), originalFee, originalGasLimit, maxGasPriceWei, opts...)
+}
+
+// GetFeeHistory returns the base fee and the given effective tip cap percentiles (0-100) of the most recent blockCount
+// blocks in the estimator's history. Blocks without usable transactions have zero rewards.
+func (b *BlockHistoryEstimator) GetFeeHistory(_ context.Context, blockCount uint64, percentiles []float64) (*FeeHistory, error) {
+	for _, p := range percentiles {
+		if p < 0 || p > 100 {
+			return nil, errors.Errorf("percentile %v is out of range, must be between 0 and 100", p)
+		}
+	}
+	blocks := b.getBlocks()
+	if len(blocks) == 0 {
+		return nil, errors.New("BlockHistoryEstimator has no blocks in history")
+	}
+	if blockCount < uint64(len(blocks)) {
+		blocks = blocks[len(blocks)-int(blockCount):]
+	}
+
+	history := &FeeHistory{}
+	if len(blocks) > 0 {
+		history.OldestBlock = blocks[0].Number
+	}
+	for _, block := range blocks {
+		history.BaseFees = append(history.BaseFees, block.BaseFeePerGas)
+
+		var tipCaps []*assets.Wei
+		for _, tx := range block.Transactions {
+			if tipCap := b.EffectiveTipCap(block, tx); tipCap != nil {
+				tipCaps = append(tipCaps, tipCap)
+			}
+		}
+		sort.Slice(tipCaps, func(i, j int) bool { return tipCaps[i].Cmp(tipCaps[j]) < 0 })
+		rewards := make([]*assets.Wei, len(percentiles))
+		for i, p := range percentiles {
+			if len(tipCaps) == 0 {
+				rewards[i] = assets.NewWeiI(0)
+				continue
+			}
+			rewards[i] = tipCaps[int(float64(len(tipCaps)-1)*p/100)]
+		}
+		history.Rewards = append(history.Rewards, rewards)
+	}
+	return history, nil
 }
 
 func (b *BlockHistoryEstimator) runLoop() {
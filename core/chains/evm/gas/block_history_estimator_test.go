@@ -20,6 +20,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/common/config"
 	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
@@ -280,6 +281,87 @@ func TestBlockHistoryEstimator_OnNewLongestChain(t *testing.T) {
 	assert.Equal(t, assets.NewWeiI(500), gas.GetLatestBaseFee(bhe))
 }
 
+func TestBlockHistoryEstimator_CurrentFees(t *testing.T) {
+	cfg := gas.NewMockConfig()
+	bhCfg := newBlockHistoryConfig()
+	geCfg := &gas.MockGasEstimatorConfig{}
+	geCfg.EIP1559DynamicFeesF = true
+
+	bhe := newBlockHistoryEstimator(t, nil, cfg, geCfg, bhCfg)
+	gas.SetTipCap(bhe, assets.NewWeiI(7))
+
+	_, _, ok := bhe.CurrentFees()
+	assert.False(t, ok, "no head has been seen yet")
+
+	h := cltest.Head(1)
+	h.BaseFeePerGas = assets.NewWeiI(500)
+	bhe.OnNewLongestChain(testutils.Context(t), h)
+
+	baseFee, tip, ok := bhe.CurrentFees()
+	require.True(t, ok)
+	assert.Equal(t, assets.NewWeiI(500), baseFee)
+	assert.Equal(t, assets.NewWeiI(7), tip)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_FeeSpike(t *testing.T) {
+	cfg := gas.NewMockConfig()
+	bhCfg := newBlockHistoryConfig()
+	bhCfg.FeeSpikeThresholdPercentF = 50
+	geCfg := &gas.MockGasEstimatorConfig{}
+	geCfg.EIP1559DynamicFeesF = true
+
+	bhe := gas.BlockHistoryEstimatorFromInterface(newBlockHistoryEstimatorWithChainID(t, nil, cfg, geCfg, bhCfg, *testutils.NewRandomEVMChainID()))
+	var events []gas.FeeSpikeEvent
+	bhe.SetFeeSpikeHandler(func(e gas.FeeSpikeEvent) {
+		events = append(events, e)
+	})
+
+	newHead := func(n int64, baseFee int64) *evmtypes.Head {
+		h := cltest.Head(n)
+		h.BaseFeePerGas = assets.NewWeiI(baseFee)
+		return h
+	}
+
+	// gradual rise, each step below the threshold
+	for i, baseFee := range []int64{100, 140, 190, 280} {
+		bhe.OnNewLongestChain(testutils.Context(t), newHead(int64(i+1), baseFee))
+	}
+	assert.Empty(t, events)
+	assert.Equal(t, float64(0), gas.GetFeeSpikeCount(bhe))
+
+	// sharp spike
+	bhe.OnNewLongestChain(testutils.Context(t), newHead(5, 500))
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(5), events[0].BlockNumber)
+	assert.Equal(t, assets.NewWeiI(280), events[0].PreviousBaseFee)
+	assert.Equal(t, assets.NewWeiI(500), events[0].CurrentBaseFee)
+	assert.Equal(t, float64(1), gas.GetFeeSpikeCount(bhe))
+
+	// exactly at the threshold does not fire
+	bhe.OnNewLongestChain(testutils.Context(t), newHead(6, 750))
+	assert.Len(t, events, 1)
+	assert.Equal(t, float64(1), gas.GetFeeSpikeCount(bhe))
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_FeeSpikeDisabled(t *testing.T) {
+	cfg := gas.NewMockConfig()
+	bhCfg := newBlockHistoryConfig()
+	geCfg := &gas.MockGasEstimatorConfig{}
+	geCfg.EIP1559DynamicFeesF = true
+
+	bhe := gas.BlockHistoryEstimatorFromInterface(newBlockHistoryEstimatorWithChainID(t, nil, cfg, geCfg, bhCfg, *testutils.NewRandomEVMChainID()))
+	bhe.SetFeeSpikeHandler(func(e gas.FeeSpikeEvent) {
+		t.Fatalf("unexpected fee spike event: %+v", e)
+	})
+
+	for i, baseFee := range []int64{100, 1000} {
+		h := cltest.Head(int64(i + 1))
+		h.BaseFeePerGas = assets.NewWeiI(baseFee)
+		bhe.OnNewLongestChain(testutils.Context(t), h)
+	}
+	assert.Equal(t, float64(0), gas.GetFeeSpikeCount(bhe))
+}
+
 func TestBlockHistoryEstimator_FetchBlocks(t *testing.T) {
 	t.Parallel()
 
@@ -1366,6 +1448,57 @@ func TestBlockHistoryEstimator_IsUsable(t *testing.T) {
 	})
 }
 
+func TestBlockHistoryEstimator_GetFeeHistory(t *testing.T) {
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	cfg := gas.NewMockConfig()
+	bhCfg := newBlockHistoryConfig()
+	geCfg := &gas.MockGasEstimatorConfig{}
+	geCfg.EIP1559DynamicFeesF = true
+	bhe := newBlockHistoryEstimator(t, ethClient, cfg, geCfg, bhCfg)
+	ctx := testutils.Context(t)
+
+	t.Run("errors without history", func(t *testing.T) {
+		_, err := bhe.GetFeeHistory(ctx, 2, []float64{50})
+		require.EqualError(t, err, "BlockHistoryEstimator has no blocks in history")
+	})
+
+	dynamicTx := func(tipCap int64) evmtypes.Transaction {
+		return evmtypes.Transaction{Type: 0x2, MaxPriorityFeePerGas: assets.NewWeiI(tipCap), MaxFeePerGas: assets.NewWeiI(1000), GasLimit: 42, Hash: utils.NewHash()}
+	}
+	blocks := []evmtypes.Block{
+		{Number: 1, Hash: utils.NewHash(), BaseFeePerGas: assets.NewWeiI(90), Transactions: []evmtypes.Transaction{dynamicTx(1)}},
+		{Number: 2, Hash: utils.NewHash(), BaseFeePerGas: assets.NewWeiI(100), Transactions: []evmtypes.Transaction{
+			dynamicTx(30),
+			{Type: 0x0, GasPrice: assets.NewWeiI(110), GasLimit: 42, Hash: utils.NewHash()},
+			dynamicTx(20),
+		}},
+		{Number: 3, Hash: utils.NewHash(), BaseFeePerGas: assets.NewWeiI(110)},
+	}
+	gas.SetRollingBlockHistory(bhe, blocks)
+
+	t.Run("returns base fees and reward percentiles of the latest blocks", func(t *testing.T) {
+		history, err := bhe.GetFeeHistory(ctx, 2, []float64{0, 50, 100})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), history.OldestBlock)
+		assert.Equal(t, []*assets.Wei{assets.NewWeiI(100), assets.NewWeiI(110)}, history.BaseFees)
+		require.Len(t, history.Rewards, 2)
+		// the legacy tx has an effective tip of gas price - base fee = 10
+		assert.Equal(t, []*assets.Wei{assets.NewWeiI(10), assets.NewWeiI(20), assets.NewWeiI(30)}, history.Rewards[0])
+		assert.Equal(t, []*assets.Wei{assets.NewWeiI(0), assets.NewWeiI(0), assets.NewWeiI(0)}, history.Rewards[1])
+	})
+	t.Run("returns all blocks if more are requested than are in history", func(t *testing.T) {
+		history, err := bhe.GetFeeHistory(ctx, 10, []float64{50})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), history.OldestBlock)
+		assert.Len(t, history.BaseFees, 3)
+		assert.Equal(t, []*assets.Wei{assets.NewWeiI(1)}, history.Rewards[0])
+	})
+	t.Run("rejects out of range percentiles", func(t *testing.T) {
+		_, err := bhe.GetFeeHistory(ctx, 2, []float64{101})
+		require.EqualError(t, err, "percentile 101 is out of range, must be between 0 and 100")
+	})
+}
+
 func TestBlockHistoryEstimator_EffectiveTipCap(t *testing.T) {
 	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
 	cfg := gas.NewMockConfig()
@@ -2034,6 +2167,16 @@ func TestBlockHistoryEstimator_GetDynamicFee(t *testing.T) {
 		assert.Equal(t, gas.DynamicFee{FeeCap: assets.NewWeiI(1000000), TipCap: assets.NewWeiI(6000)}, fee)
 		assert.Equal(t, 100000, int(limit))
 	})
+
+	t.Run("uses an injected base fee instead of the latest block's", func(t *testing.T) {
+		geCfg.BumpThresholdF = uint64(1)
+
+		fee, limit, err := bhe.GetDynamicFee(testutils.Context(t), 100000, maxGasPrice, feetypes.WithBaseFee(assets.NewWeiI(112500)))
+		require.NoError(t, err)
+
+		assert.Equal(t, gas.DynamicFee{FeeCap: assets.NewWeiI(186203), TipCap: assets.NewWeiI(6000)}, fee)
+		assert.Equal(t, 100000, int(limit))
+	})
 }
 
 func TestBlockHistoryEstimator_CheckConnectivity(t *testing.T) {
@@ -2557,3 +2700,38 @@ func TestBlockHistoryEstimator_Bumps(t *testing.T) {
 		})
 	})
 }
+
+func TestBlockHistoryEstimator_OnReorg(t *testing.T) {
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	cfg := gas.NewMockConfig()
+	bhCfg := newBlockHistoryConfig()
+	geCfg := &gas.MockGasEstimatorConfig{}
+	bhe := newBlockHistoryEstimator(t, ethClient, cfg, geCfg, bhCfg)
+	ctx := testutils.Context(t)
+
+	blocks := func() []evmtypes.Block {
+		return []evmtypes.Block{
+			{Number: 1, Hash: utils.NewHash()},
+			{Number: 2, Hash: utils.NewHash()},
+			{Number: 3, Hash: utils.NewHash()},
+			{Number: 4, Hash: utils.NewHash()},
+		}
+	}
+
+	t.Run("drops blocks above the common ancestor", func(t *testing.T) {
+		gas.SetRollingBlockHistory(bhe, blocks())
+		bhe.OnReorg(ctx, &evmtypes.Head{Number: 4, Hash: utils.NewHash()}, &evmtypes.Head{Number: 2})
+
+		history := gas.GetRollingBlockHistory(bhe)
+		require.Len(t, history, 2)
+		assert.Equal(t, int64(1), history[0].Number)
+		assert.Equal(t, int64(2), history[1].Number)
+	})
+
+	t.Run("clears the history when the common ancestor is unknown", func(t *testing.T) {
+		gas.SetRollingBlockHistory(bhe, blocks())
+		bhe.OnReorg(ctx, &evmtypes.Head{Number: 4, Hash: utils.NewHash()}, nil)
+
+		assert.Empty(t, gas.GetRollingBlockHistory(bhe))
+	})
+}
@@ -0,0 +1,97 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+var _ EvmFeeEstimator = (*cappedFeeEstimator)(nil)
+
+// cappedFeeEstimator clamps every fee served by the inner estimator to a global max, independent of the max gas price
+// for each key, as a last resort guard against a runaway estimator. All other calls are delegated to the inner estimator.
+type cappedFeeEstimator struct {
+	EvmFeeEstimator
+	lggr      logger.SugaredLogger
+	globalMax *assets.Wei
+}
+
+// NewCappedFeeEstimator returns an EvmFeeEstimator that never serves a gas price, fee cap or tip cap above globalMax.
+// The max fee price passed to inner is also capped, so bumps and max costs respect the global max.
+func NewCappedFeeEstimator(lggr logger.Logger, inner EvmFeeEstimator, globalMax *assets.Wei) EvmFeeEstimator {
+	return &cappedFeeEstimator{
+		EvmFeeEstimator: inner,
+		lggr:            logger.Sugared(lggr.Named("CappedFeeEstimator")),
+		globalMax:       globalMax,
+	}
+}
+
+func (e *cappedFeeEstimator) Name() string {
+	return fmt.Sprintf("CappedFeeEstimator(%s)", e.EvmFeeEstimator.Name())
+}
+
+func (e *cappedFeeEstimator) GetFee(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err = e.EvmFeeEstimator.GetFee(ctx, calldata, feeLimit, e.capMax(maxFeePrice), opts...)
+	return e.clamp(fee, "GetFee"), chainSpecificFeeLimit, err
+}
+
+func (e *cappedFeeEstimator) GetFeeForType(ctx context.Context, txType int, toAddress common.Address, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err = e.EvmFeeEstimator.GetFeeForType(ctx, txType, toAddress, calldata, feeLimit, e.capMax(maxFeePrice), opts...)
+	return e.clamp(fee, "GetFeeForType"), chainSpecificFeeLimit, err
+}
+
+func (e *cappedFeeEstimator) GetFeeCandidates(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (candidates []EvmFee, chainSpecificFeeLimit uint32, err error) {
+	candidates, chainSpecificFeeLimit, err = e.EvmFeeEstimator.GetFeeCandidates(ctx, calldata, feeLimit, e.capMax(maxFeePrice), opts...)
+	for i := range candidates {
+		candidates[i] = e.clamp(candidates[i], "GetFeeCandidates")
+	}
+	return candidates, chainSpecificFeeLimit, err
+}
+
+func (e *cappedFeeEstimator) BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	bumpedFee, chainSpecificFeeLimit, err = e.EvmFeeEstimator.BumpFee(ctx, originalFee, feeLimit, e.capMax(maxFeePrice), attempts, opts...)
+	return e.clamp(bumpedFee, "BumpFee"), chainSpecificFeeLimit, err
+}
+
+func (e *cappedFeeEstimator) BumpToFee(ctx context.Context, target EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	return e.EvmFeeEstimator.BumpToFee(ctx, target, feeLimit, e.capMax(maxFeePrice), attempts)
+}
+
+func (e *cappedFeeEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (*big.Int, error) {
+	return e.EvmFeeEstimator.GetMaxCost(ctx, amount, calldata, feeLimit, e.capMax(maxFeePrice), opts...)
+}
+
+func (e *cappedFeeEstimator) GetMaxCostBreakdown(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (l2 *big.Int, l1Min *big.Int, err error) {
+	return e.EvmFeeEstimator.GetMaxCostBreakdown(ctx, amount, calldata, feeLimit, e.capMax(maxFeePrice), opts...)
+}
+
+// capMax returns the lower of maxFeePrice and the global max
+func (e *cappedFeeEstimator) capMax(maxFeePrice *assets.Wei) *assets.Wei {
+	if maxFeePrice == nil {
+		return e.globalMax
+	}
+	return assets.WeiMin(maxFeePrice, e.globalMax)
+}
+
+// clamp caps each component of fee at the global max, logging if any was above it
+func (e *cappedFeeEstimator) clamp(fee EvmFee, method string) EvmFee {
+	clamped, wasAbove := fee, false
+	clampWei := func(w *assets.Wei) *assets.Wei {
+		if w == nil || w.Cmp(e.globalMax) <= 0 {
+			return w
+		}
+		wasAbove = true
+		return e.globalMax
+	}
+	clamped.Legacy, clamped.DynamicFeeCap, clamped.DynamicTipCap = clampWei(fee.Legacy), clampWei(fee.DynamicFeeCap), clampWei(fee.DynamicTipCap)
+	if wasAbove {
+		e.lggr.Warnw(fmt.Sprintf("Estimator returned a fee above the global max from %s, clamping", method), "fee", fee.String(), "globalMax", e.globalMax, "clampedFee", clamped.String())
+	}
+	return clamped
+}
@@ -0,0 +1,72 @@
+package gas_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestCappedFeeEstimator(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	globalMax := assets.GWei(50)
+	keyMax := assets.GWei(100)
+
+	t.Run("clamps a legacy fee above the global max", func(t *testing.T) {
+		lggr, observed := logger.TestLoggerObserved(t, zapcore.WarnLevel)
+		inner := mocks.NewEvmFeeEstimator(t)
+		inner.On("GetFee", mock.Anything, mock.Anything, uint32(21000), globalMax).Return(gas.NewLegacyFee(assets.GWei(80)), uint32(21000), nil).Once()
+		e := gas.NewCappedFeeEstimator(lggr, inner, globalMax)
+
+		fee, limit, err := e.GetFee(ctx, nil, 21000, keyMax)
+		require.NoError(t, err)
+		assert.Equal(t, gas.NewLegacyFee(globalMax), fee)
+		assert.Equal(t, uint32(21000), limit)
+		assert.Equal(t, 1, observed.FilterMessageSnippet("above the global max from GetFee").Len())
+	})
+
+	t.Run("clamps each component of a bumped dynamic fee", func(t *testing.T) {
+		lggr, observed := logger.TestLoggerObserved(t, zapcore.WarnLevel)
+		inner := mocks.NewEvmFeeEstimator(t)
+		original := gas.NewDynamicFee(assets.GWei(40), assets.GWei(20))
+		inner.On("BumpFee", mock.Anything, original, uint32(21000), globalMax, mock.Anything).Return(gas.NewDynamicFee(assets.GWei(60), assets.GWei(55)), uint32(21000), nil).Once()
+		e := gas.NewCappedFeeEstimator(lggr, inner, globalMax)
+
+		fee, _, err := e.BumpFee(ctx, original, 21000, keyMax, nil)
+		require.NoError(t, err)
+		assert.Equal(t, gas.NewDynamicFee(globalMax, globalMax), fee)
+		assert.Equal(t, 1, observed.FilterMessageSnippet("above the global max from BumpFee").Len())
+	})
+
+	t.Run("passes fees below the global max through", func(t *testing.T) {
+		lggr, observed := logger.TestLoggerObserved(t, zapcore.WarnLevel)
+		inner := mocks.NewEvmFeeEstimator(t)
+		lowKeyMax := assets.GWei(30)
+		inner.On("GetFee", mock.Anything, mock.Anything, uint32(21000), lowKeyMax).Return(gas.NewLegacyFee(assets.GWei(25)), uint32(21000), nil).Once()
+		e := gas.NewCappedFeeEstimator(lggr, inner, globalMax)
+
+		fee, _, err := e.GetFee(ctx, nil, 21000, lowKeyMax)
+		require.NoError(t, err)
+		assert.Equal(t, gas.NewLegacyFee(assets.GWei(25)), fee)
+		assert.Equal(t, 0, observed.Len())
+	})
+
+	t.Run("delegates everything else", func(t *testing.T) {
+		inner := mocks.NewEvmFeeEstimator(t)
+		inner.On("Name").Return("Inner")
+		inner.On("SupportsEIP1559").Return(true).Once()
+		e := gas.NewCappedFeeEstimator(logger.TestLogger(t), inner, globalMax)
+
+		assert.Equal(t, "CappedFeeEstimator(Inner)", e.Name())
+		assert.True(t, e.SupportsEIP1559())
+	})
+}
@@ -0,0 +1,234 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/rollups"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+var _ EvmFeeEstimator = (*fallbackFeeEstimator)(nil)
+
+// fallbackFeeEstimator serves fees from the primary estimator, retrying with the secondary if the primary errors or
+// returns an empty fee, e.g. to fall back to a fixed price while block history is unavailable. Errors the primary
+// returns on purpose to stop bumping are passed through, so the secondary cannot bump past them
+type fallbackFeeEstimator struct {
+	services.StateMachine
+	lggr      logger.SugaredLogger
+	primary   EvmFeeEstimator
+	secondary EvmFeeEstimator
+}
+
+// NewFallbackFeeEstimator returns an EvmFeeEstimator that delegates to primary, and to secondary if primary fails
+func NewFallbackFeeEstimator(lggr logger.Logger, primary, secondary EvmFeeEstimator) EvmFeeEstimator {
+	return &fallbackFeeEstimator{
+		lggr:      logger.Sugared(lggr.Named("FallbackFeeEstimator")),
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+func (e *fallbackFeeEstimator) Name() string {
+	return fmt.Sprintf("FallbackFeeEstimator(%s, %s)", e.primary.Name(), e.secondary.Name())
+}
+
+func (e *fallbackFeeEstimator) Start(ctx context.Context) error {
+	return e.StartOnce(e.Name(), func() error {
+		if err := e.primary.Start(ctx); err != nil {
+			return errors.Wrap(err, "failed to start primary estimator")
+		}
+		if err := e.secondary.Start(ctx); err != nil {
+			return errors.Wrap(err, "failed to start secondary estimator")
+		}
+		return nil
+	})
+}
+
+func (e *fallbackFeeEstimator) Close() error {
+	return e.StopOnce(e.Name(), func() error {
+		errPrimary := errors.Wrap(e.primary.Close(), "failed to stop primary estimator")
+		errSecondary := errors.Wrap(e.secondary.Close(), "failed to stop secondary estimator")
+		if errPrimary != nil {
+			return errPrimary
+		}
+		return errSecondary
+	})
+}
+
+func (e *fallbackFeeEstimator) Ready() error {
+	if err := e.primary.Ready(); err != nil {
+		return err
+	}
+	return e.secondary.Ready()
+}
+
+func (e *fallbackFeeEstimator) HealthReport() map[string]error {
+	report := map[string]error{e.Name(): e.Healthy()}
+	services.CopyHealth(report, e.primary.HealthReport())
+	services.CopyHealth(report, e.secondary.HealthReport())
+	return report
+}
+
+// OnNewLongestChain keeps both estimators up to date so the secondary is ready to serve as soon as it is needed
+func (e *fallbackFeeEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	e.primary.OnNewLongestChain(ctx, head)
+	e.secondary.OnNewLongestChain(ctx, head)
+}
+
+func (e *fallbackFeeEstimator) OnReorg(ctx context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head) {
+	e.primary.OnReorg(ctx, head, commonAncestor)
+	e.secondary.OnReorg(ctx, head, commonAncestor)
+}
+
+// SupportsEIP1559 requires both estimators to support dynamic fees, since either may end up serving them
+func (e *fallbackFeeEstimator) SupportsEIP1559() bool {
+	return e.primary.SupportsEIP1559() && e.secondary.SupportsEIP1559()
+}
+
+// CurrentFees reports the primary estimator's view, falling back to the secondary if the primary has none
+func (e *fallbackFeeEstimator) CurrentFees() (baseFee *assets.Wei, suggestedTip *assets.Wei, ok bool) {
+	if baseFee, suggestedTip, ok = e.primary.CurrentFees(); ok {
+		return
+	}
+	return e.secondary.CurrentFees()
+}
+
+// Warm waits for the primary estimator, since the secondary only serves fees while the primary is failing
+func (e *fallbackFeeEstimator) Warm(ctx context.Context, headsNeeded int) error {
+	return e.primary.Warm(ctx, headsNeeded)
+}
+
+func (e *fallbackFeeEstimator) IsWarm() bool {
+	return e.primary.IsWarm()
+}
+
+func (e *fallbackFeeEstimator) L1Oracle() rollups.L1Oracle {
+	if o := e.primary.L1Oracle(); o != nil {
+		return o
+	}
+	return e.secondary.L1Oracle()
+}
+
+func (e *fallbackFeeEstimator) GetFee(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err = e.primary.GetFee(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	var fallback bool
+	if fallback, err = e.checkFee(fee, err, "GetFee"); err == nil {
+		return fee, chainSpecificFeeLimit, nil
+	} else if !fallback {
+		return fee, chainSpecificFeeLimit, err
+	}
+	return e.secondary.GetFee(ctx, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+func (e *fallbackFeeEstimator) GetFeeForType(ctx context.Context, txType int, toAddress common.Address, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err = e.primary.GetFeeForType(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+	var fallback bool
+	if fallback, err = e.checkFee(fee, err, "GetFeeForType"); err == nil {
+		return fee, chainSpecificFeeLimit, nil
+	} else if !fallback {
+		return fee, chainSpecificFeeLimit, err
+	}
+	return e.secondary.GetFeeForType(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+func (e *fallbackFeeEstimator) GetFeeCandidates(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (candidates []EvmFee, chainSpecificFeeLimit uint32, err error) {
+	candidates, chainSpecificFeeLimit, err = e.primary.GetFeeCandidates(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	// candidates are ordered cheapest first, so if the first is valid they all are
+	var cheapest EvmFee
+	if len(candidates) > 0 {
+		cheapest = candidates[0]
+	}
+	var fallback bool
+	if fallback, err = e.checkFee(cheapest, err, "GetFeeCandidates"); err == nil {
+		return candidates, chainSpecificFeeLimit, nil
+	} else if !fallback {
+		return candidates, chainSpecificFeeLimit, err
+	}
+	return e.secondary.GetFeeCandidates(ctx, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+func (e *fallbackFeeEstimator) BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	bumpedFee, chainSpecificFeeLimit, err = e.primary.BumpFee(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+	var fallback bool
+	if fallback, err = e.checkFee(bumpedFee, err, "BumpFee"); err == nil {
+		return bumpedFee, chainSpecificFeeLimit, nil
+	} else if !fallback {
+		return bumpedFee, chainSpecificFeeLimit, err
+	}
+	return e.secondary.BumpFee(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+}
+
+// BumpToFee only validates the target, so there is nothing for the secondary to fall back on
+func (e *fallbackFeeEstimator) BumpToFee(ctx context.Context, target EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	return e.primary.BumpToFee(ctx, target, feeLimit, maxFeePrice, attempts)
+}
+
+func (e *fallbackFeeEstimator) EstimateGasLimit(ctx context.Context, calldata []byte, feeLimit uint32, opts ...feetypes.Opt) (uint32, error) {
+	limit, err := e.primary.EstimateGasLimit(ctx, calldata, feeLimit, opts...)
+	if err == nil {
+		return limit, nil
+	}
+	e.lggr.Warnw("Primary estimator failed EstimateGasLimit, falling back to secondary", "err", err)
+	return e.secondary.EstimateGasLimit(ctx, calldata, feeLimit, opts...)
+}
+
+func (e *fallbackFeeEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (*big.Int, error) {
+	cost, err := e.primary.GetMaxCost(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	if err == nil {
+		return cost, nil
+	}
+	e.lggr.Warnw("Primary estimator failed GetMaxCost, falling back to secondary", "err", err)
+	return e.secondary.GetMaxCost(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+func (e *fallbackFeeEstimator) GetMaxCostBreakdown(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (l2 *big.Int, l1Min *big.Int, err error) {
+	l2, l1Min, err = e.primary.GetMaxCostBreakdown(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	if err == nil {
+		return l2, l1Min, nil
+	}
+	e.lggr.Warnw("Primary estimator failed GetMaxCostBreakdown, falling back to secondary", "err", err)
+	return e.secondary.GetMaxCostBreakdown(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+func (e *fallbackFeeEstimator) GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*FeeHistory, error) {
+	history, err := e.primary.GetFeeHistory(ctx, blockCount, percentiles)
+	if err == nil {
+		return history, nil
+	}
+	return e.secondary.GetFeeHistory(ctx, blockCount, percentiles)
+}
+
+// checkFee returns an error if the primary estimator failed or returned a fee with no positive component. fallback
+// reports whether the secondary should be used instead: it is false for errors that deliberately stop a bump, i.e.
+// the bump would exceed the max fee price or prior attempts are already priced high enough but are not being mined
+func (e *fallbackFeeEstimator) checkFee(fee EvmFee, err error, method string) (fallback bool, _ error) {
+	if err == nil && !isPositiveFee(fee) {
+		err = errors.Errorf("estimator returned invalid fee %s", fee)
+	}
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, commonfee.ErrBumpFeeExceedsLimit) || errors.Is(err, commonfee.ErrConnectivity) {
+		return false, err
+	}
+	e.lggr.Warnw(fmt.Sprintf("Primary estimator failed %s, falling back to secondary", method), "err", err)
+	return true, err
+}
+
+func isPositiveFee(fee EvmFee) bool {
+	if fee.Legacy != nil && fee.Legacy.Cmp(assets.NewWeiI(0)) > 0 {
+		return true
+	}
+	return fee.ValidDynamic() && fee.DynamicFeeCap.Cmp(assets.NewWeiI(0)) > 0
+}
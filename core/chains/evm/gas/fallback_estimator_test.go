@@ -0,0 +1,157 @@
+package gas_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestFallbackFeeEstimator(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	maxPrice := assets.NewWeiI(100)
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(10)}
+
+	t.Run("uses the primary when it succeeds", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(fee, uint32(21000), nil).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		got, limit, err := e.GetFee(ctx, nil, 21000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, fee, got)
+		assert.Equal(t, uint32(21000), limit)
+	})
+
+	t.Run("falls back to the secondary when the primary errors", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(gas.EvmFee{}, uint32(0), errors.New("no blocks")).Once()
+		secondary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(fee, uint32(21000), nil).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		got, _, err := e.GetFee(ctx, nil, 21000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, fee, got)
+	})
+
+	t.Run("falls back to the secondary when the primary returns a zero fee", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(gas.EvmFee{Legacy: assets.NewWeiI(0)}, uint32(21000), nil).Once()
+		secondary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(fee, uint32(21000), nil).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		got, _, err := e.GetFee(ctx, nil, 21000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, fee, got)
+	})
+
+	t.Run("returns the secondary error when both fail", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(gas.EvmFee{}, uint32(0), errors.New("primary")).Once()
+		secondary.On("GetFee", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(gas.EvmFee{}, uint32(0), errors.New("secondary")).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		_, _, err := e.GetFee(ctx, nil, 21000, maxPrice)
+		require.EqualError(t, err, "secondary")
+	})
+
+	t.Run("falls back to the secondary for BumpFee", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		bumped := gas.EvmFee{Legacy: assets.NewWeiI(20)}
+		primary.On("BumpFee", mock.Anything, fee, uint32(21000), maxPrice, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("no blocks")).Once()
+		secondary.On("BumpFee", mock.Anything, fee, uint32(21000), maxPrice, mock.Anything).Return(bumped, uint32(21000), nil).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		got, _, err := e.BumpFee(ctx, fee, 21000, maxPrice, nil)
+		require.NoError(t, err)
+		assert.Equal(t, bumped, got)
+	})
+
+	t.Run("does not fall back to the secondary when the primary stops a bump", func(t *testing.T) {
+		for _, stopErr := range []error{commonfee.ErrConnectivity, commonfee.ErrBumpFeeExceedsLimit} {
+			primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+			primary.On("BumpFee", mock.Anything, fee, uint32(21000), maxPrice, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.Wrap(stopErr, "primary")).Once()
+			e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+			_, _, err := e.BumpFee(ctx, fee, 21000, maxPrice, nil)
+			require.ErrorIs(t, err, stopErr)
+			secondary.AssertNotCalled(t, "BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("fans out OnNewLongestChain and merges Name and HealthReport", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		head := &evmtypes.Head{Number: 1}
+		primary.On("OnNewLongestChain", mock.Anything, head).Once()
+		secondary.On("OnNewLongestChain", mock.Anything, head).Once()
+		primary.On("Name").Return("primary")
+		secondary.On("Name").Return("secondary")
+		primary.On("HealthReport").Return(map[string]error{"primary": nil})
+		secondary.On("HealthReport").Return(map[string]error{"secondary": errors.New("unhealthy")})
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		e.OnNewLongestChain(ctx, head)
+		assert.Equal(t, "FallbackFeeEstimator(primary, secondary)", e.Name())
+		report := e.HealthReport()
+		assert.Contains(t, report, "primary")
+		assert.EqualError(t, report["secondary"], "unhealthy")
+	})
+
+	t.Run("supports EIP-1559 only if both estimators do", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("SupportsEIP1559").Return(true)
+		secondary.On("SupportsEIP1559").Return(false).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+		assert.False(t, e.SupportsEIP1559())
+
+		secondary.On("SupportsEIP1559").Return(true).Once()
+		assert.True(t, e.SupportsEIP1559())
+	})
+
+	t.Run("falls back to the secondary for GetFeeCandidates", func(t *testing.T) {
+		candidates := []gas.EvmFee{gas.NewLegacyFee(assets.NewWeiI(8)), fee, gas.NewLegacyFee(assets.NewWeiI(13))}
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("GetFeeCandidates", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(nil, uint32(0), errors.New("no blocks")).Once()
+		secondary.On("GetFeeCandidates", mock.Anything, mock.Anything, uint32(21000), maxPrice).Return(candidates, uint32(21000), nil).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		got, limit, err := e.GetFeeCandidates(ctx, nil, 21000, maxPrice)
+		require.NoError(t, err)
+		assert.Equal(t, candidates, got)
+		assert.Equal(t, uint32(21000), limit)
+	})
+
+	t.Run("reports the primary's current fees, falling back to the secondary's", func(t *testing.T) {
+		primary, secondary := mocks.NewEvmFeeEstimator(t), mocks.NewEvmFeeEstimator(t)
+		primary.On("CurrentFees").Return(assets.NewWeiI(30), assets.NewWeiI(2), true).Once()
+		e := gas.NewFallbackFeeEstimator(logger.TestLogger(t), primary, secondary)
+
+		baseFee, tip, ok := e.CurrentFees()
+		require.True(t, ok)
+		assert.Equal(t, assets.NewWeiI(30), baseFee)
+		assert.Equal(t, assets.NewWeiI(2), tip)
+
+		primary.On("CurrentFees").Return(nil, nil, false).Once()
+		secondary.On("CurrentFees").Return(assets.NewWeiI(40), assets.NewWeiI(3), true).Once()
+		baseFee, tip, ok = e.CurrentFees()
+		require.True(t, ok)
+		assert.Equal(t, assets.NewWeiI(40), baseFee)
+		assert.Equal(t, assets.NewWeiI(3), tip)
+
+		primary.On("CurrentFees").Return(nil, nil, false).Once()
+		secondary.On("CurrentFees").Return(nil, nil, false).Once()
+		_, _, ok = e.CurrentFees()
+		assert.False(t, ok)
+	})
+}
@@ -67,6 +67,10 @@ func (f *fixedPriceEstimator) GetLegacyGas(_ context.Context, _ []byte, gasLimit
 	return assets.NewWei(gasPrice), chainSpecificGasLimit, nil
 }
 
+func (f *fixedPriceEstimator) EstimateGasLimit(_ context.Context, _ []byte, gasLimit uint32, _ ...feetypes.Opt) (uint32, error) {
+	return commonfee.ApplyMultiplier(gasLimit, f.config.LimitMultiplier())
+}
+
 func (f *fixedPriceEstimator) BumpLegacyGas(
 	_ context.Context,
 	originalGasPrice *assets.Wei,
@@ -95,7 +99,7 @@ func (f *fixedPriceEstimator) BumpLegacyGas(
 	return assets.NewWei(gasPrice), chainSpecificGasLimit, err
 }
 
-func (f *fixedPriceEstimator) GetDynamicFee(_ context.Context, originalGasLimit uint32, maxGasPriceWei *assets.Wei) (d DynamicFee, chainSpecificGasLimit uint32, err error) {
+func (f *fixedPriceEstimator) GetDynamicFee(_ context.Context, originalGasLimit uint32, maxGasPriceWei *assets.Wei, _ ...feetypes.Opt) (d DynamicFee, chainSpecificGasLimit uint32, err error) {
 	gasTipCap := f.config.TipCapDefault()
 
 	if gasTipCap == nil {
@@ -127,17 +131,20 @@ func (f *fixedPriceEstimator) BumpDynamicFee(
 	originalGasLimit uint32,
 	maxGasPriceWei *assets.Wei,
 	_ []EvmPriorAttempt,
+	opts ...feetypes.Opt,
 ) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
-
+	// the fixed price estimator does not track the base fee, so only an injected one is considered
+	baseFee, _ := feetypes.BaseFee[*assets.Wei](opts)
 	return BumpDynamicFeeOnly(
 		f.config,
 		f.bhConfig.EIP1559FeeCapBufferBlocks(),
 		f.lggr,
 		f.config.TipCapDefault(),
-		nil,
+		baseFee,
 		originalFee,
 		originalGasLimit,
 		maxGasPriceWei,
+		opts...,
 	)
 }
 
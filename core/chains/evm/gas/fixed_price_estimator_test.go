@@ -38,6 +38,16 @@ func Test_FixedPriceEstimator(t *testing.T) {
 		assert.Equal(t, assets.NewWeiI(42), gasPrice)
 	})
 
+	t.Run("EstimateGasLimit returns the gas limit with multiplier applied", func(t *testing.T) {
+		config := &gas.MockGasEstimatorConfig{}
+		config.LimitMultiplierF = float32(1.1)
+		f := gas.NewFixedPriceEstimator(config, &blockHistoryConfig{}, logger.TestLogger(t))
+
+		gasLimit, err := f.EstimateGasLimit(testutils.Context(t), nil, 100000)
+		require.NoError(t, err)
+		assert.Equal(t, 110000, int(gasLimit))
+	})
+
 	t.Run("GetLegacyGas returns user specified maximum gas price", func(t *testing.T) {
 		config := &gas.MockGasEstimatorConfig{}
 		config.PriceDefaultF = assets.NewWeiI(42)
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -105,6 +107,22 @@ func Test_BumpLegacyGasPriceOnly(t *testing.T) {
 	}
 }
 
+func Test_BumpLegacyGasPriceOnly_RoundsUp(t *testing.T) {
+	t.Parallel()
+
+	priceMax := assets.GWei(40)
+	cfg := &gas.MockGasEstimatorConfig{}
+	cfg.BumpPercentF = uint16(10)
+	cfg.BumpMinF = assets.NewWeiI(0)
+	cfg.PriceMaxF = priceMax
+	cfg.LimitMultiplierF = 1.0
+
+	// 25 wei + 10% = 27.5 wei, which must not be truncated below the configured bump
+	actual, _, err := gas.BumpLegacyGasPriceOnly(cfg, logger.TestLogger(t), nil, assets.NewWeiI(25), 42, priceMax)
+	require.NoError(t, err)
+	assert.Equal(t, "28 wei", actual.String())
+}
+
 func Test_BumpLegacyGasPriceOnly_HitsMaxError(t *testing.T) {
 	t.Parallel()
 
@@ -337,6 +355,35 @@ func Test_BumpDynamicFeeOnly_HitsMaxError(t *testing.T) {
 	})
 }
 
+func Test_BumpDynamicFeeOnly_TipOnly(t *testing.T) {
+	t.Parallel()
+
+	priceMax := assets.GWei(100)
+
+	cfg := &gas.MockGasEstimatorConfig{}
+	cfg.BumpPercentF = uint16(10)
+	cfg.TipCapDefaultF = assets.GWei(0)
+	cfg.BumpMinF = assets.GWei(1)
+	cfg.PriceMaxF = priceMax
+
+	t.Run("bumps the tip cap with the fee cap near the max", func(t *testing.T) {
+		originalFee := gas.DynamicFee{TipCap: assets.GWei(10), FeeCap: assets.GWei(95)}
+		_, _, err := gas.BumpDynamicFeeOnly(cfg, 0, logger.TestLogger(t), nil, nil, originalFee, 42, priceMax)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+
+		bumped, _, err := gas.BumpDynamicFeeOnly(cfg, 0, logger.TestLogger(t), nil, nil, originalFee, 42, priceMax, feetypes.OptBumpTipOnly)
+		require.NoError(t, err)
+		assert.Equal(t, gas.DynamicFee{TipCap: assets.GWei(11), FeeCap: assets.GWei(95)}, bumped)
+	})
+
+	t.Run("errors if the bumped tip cap exceeds the fee cap", func(t *testing.T) {
+		originalFee := gas.DynamicFee{TipCap: assets.GWei(20), FeeCap: assets.GWei(21)}
+		_, _, err := gas.BumpDynamicFeeOnly(cfg, 0, logger.TestLogger(t), nil, nil, originalFee, 42, priceMax, feetypes.OptBumpTipOnly)
+		require.ErrorIs(t, err, commonfee.ErrBump)
+		require.Contains(t, err.Error(), "bumped tip cap of 22 gwei would exceed the fixed fee cap of 21 gwei")
+	})
+}
+
 // toWei is used to convert scientific notation string to a *assets.Wei
 func toWei(input string) *assets.Wei {
 	flt, _, err := big.ParseFloat(input, 10, 0, big.ToNearestEven)
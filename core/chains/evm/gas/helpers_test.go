@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/v2/common/config"
@@ -67,6 +68,10 @@ func GetLatestBaseFee(b *BlockHistoryEstimator) *assets.Wei {
 	return b.latest.BaseFeePerGas
 }
 
+func GetFeeSpikeCount(b *BlockHistoryEstimator) float64 {
+	return promtestutil.ToFloat64(promBlockHistoryEstimatorFeeSpikeCount.WithLabelValues(b.chainID.String()))
+}
+
 func SimulateStart(t *testing.T, b *BlockHistoryEstimator) {
 	require.NoError(t, b.StartOnce("BlockHistoryEstimatorSimulatedStart", func() error { return nil }))
 }
@@ -79,6 +84,7 @@ type MockBlockHistoryConfig struct {
 	CheckInclusionPercentileF  uint16
 	EIP1559FeeCapBufferBlocksF uint16
 	TransactionPercentileF     uint16
+	FeeSpikeThresholdPercentF  uint16
 	FinalityTagEnabledF        bool
 }
 
@@ -110,6 +116,10 @@ func (m *MockBlockHistoryConfig) TransactionPercentile() uint16 {
 	return m.TransactionPercentileF
 }
 
+func (m *MockBlockHistoryConfig) FeeSpikeThresholdPercent() uint16 {
+	return m.FeeSpikeThresholdPercentF
+}
+
 type MockConfig struct {
 	ChainTypeF          string
 	FinalityTagEnabledF bool
@@ -21,30 +21,37 @@ type EvmEstimator struct {
 	mock.Mock
 }
 
-// BumpDynamicFee provides a mock function with given fields: ctx, original, gasLimit, maxGasPriceWei, attempts
-func (_m *EvmEstimator) BumpDynamicFee(ctx context.Context, original gas.DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []gas.EvmPriorAttempt) (gas.DynamicFee, uint32, error) {
-	ret := _m.Called(ctx, original, gasLimit, maxGasPriceWei, attempts)
+// BumpDynamicFee provides a mock function with given fields: ctx, original, gasLimit, maxGasPriceWei, attempts, opts
+func (_m *EvmEstimator) BumpDynamicFee(ctx context.Context, original gas.DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []gas.EvmPriorAttempt, opts ...types.Opt) (gas.DynamicFee, uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, original, gasLimit, maxGasPriceWei, attempts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 gas.DynamicFee
 	var r1 uint32
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) (gas.DynamicFee, uint32, error)); ok {
-		return rf(ctx, original, gasLimit, maxGasPriceWei, attempts)
+	if rf, ok := ret.Get(0).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) (gas.DynamicFee, uint32, error)); ok {
+		return rf(ctx, original, gasLimit, maxGasPriceWei, attempts, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) gas.DynamicFee); ok {
-		r0 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts)
+	if rf, ok := ret.Get(0).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) gas.DynamicFee); ok {
+		r0 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts, opts...)
 	} else {
 		r0 = ret.Get(0).(gas.DynamicFee)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) uint32); ok {
-		r1 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts)
+	if rf, ok := ret.Get(1).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) uint32); ok {
+		r1 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts, opts...)
 	} else {
 		r1 = ret.Get(1).(uint32)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) error); ok {
-		r2 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts)
+	if rf, ok := ret.Get(2).(func(context.Context, gas.DynamicFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) error); ok {
+		r2 = rf(ctx, original, gasLimit, maxGasPriceWei, attempts, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -99,30 +106,68 @@ func (_m *EvmEstimator) Close() error {
 	return r0
 }
 
-// GetDynamicFee provides a mock function with given fields: ctx, gasLimit, maxGasPriceWei
-func (_m *EvmEstimator) GetDynamicFee(ctx context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei) (gas.DynamicFee, uint32, error) {
-	ret := _m.Called(ctx, gasLimit, maxGasPriceWei)
+// EstimateGasLimit provides a mock function with given fields: ctx, calldata, gasLimit, opts
+func (_m *EvmEstimator) EstimateGasLimit(ctx context.Context, calldata []byte, gasLimit uint32, opts ...types.Opt) (uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, calldata, gasLimit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, ...types.Opt) (uint32, error)); ok {
+		return rf(ctx, calldata, gasLimit, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, ...types.Opt) uint32); ok {
+		r0 = rf(ctx, calldata, gasLimit, opts...)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, uint32, ...types.Opt) error); ok {
+		r1 = rf(ctx, calldata, gasLimit, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDynamicFee provides a mock function with given fields: ctx, gasLimit, maxGasPriceWei, opts
+func (_m *EvmEstimator) GetDynamicFee(ctx context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei, opts ...types.Opt) (gas.DynamicFee, uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, gasLimit, maxGasPriceWei)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 gas.DynamicFee
 	var r1 uint32
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, *assets.Wei) (gas.DynamicFee, uint32, error)); ok {
-		return rf(ctx, gasLimit, maxGasPriceWei)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, *assets.Wei, ...types.Opt) (gas.DynamicFee, uint32, error)); ok {
+		return rf(ctx, gasLimit, maxGasPriceWei, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, *assets.Wei) gas.DynamicFee); ok {
-		r0 = rf(ctx, gasLimit, maxGasPriceWei)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, *assets.Wei, ...types.Opt) gas.DynamicFee); ok {
+		r0 = rf(ctx, gasLimit, maxGasPriceWei, opts...)
 	} else {
 		r0 = ret.Get(0).(gas.DynamicFee)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32, *assets.Wei) uint32); ok {
-		r1 = rf(ctx, gasLimit, maxGasPriceWei)
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, *assets.Wei, ...types.Opt) uint32); ok {
+		r1 = rf(ctx, gasLimit, maxGasPriceWei, opts...)
 	} else {
 		r1 = ret.Get(1).(uint32)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uint32, *assets.Wei) error); ok {
-		r2 = rf(ctx, gasLimit, maxGasPriceWei)
+	if rf, ok := ret.Get(2).(func(context.Context, uint32, *assets.Wei, ...types.Opt) error); ok {
+		r2 = rf(ctx, gasLimit, maxGasPriceWei, opts...)
 	} else {
 		r2 = ret.Error(2)
 	}
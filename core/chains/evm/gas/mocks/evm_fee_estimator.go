@@ -7,6 +7,8 @@ import (
 
 	assets "github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 
+	common "github.com/ethereum/go-ethereum/common"
+
 	context "context"
 
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
@@ -25,30 +27,68 @@ type EvmFeeEstimator struct {
 	mock.Mock
 }
 
-// BumpFee provides a mock function with given fields: ctx, originalFee, feeLimit, maxFeePrice, attempts
-func (_m *EvmFeeEstimator) BumpFee(ctx context.Context, originalFee gas.EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []gas.EvmPriorAttempt) (gas.EvmFee, uint32, error) {
-	ret := _m.Called(ctx, originalFee, feeLimit, maxFeePrice, attempts)
+// BumpFee provides a mock function with given fields: ctx, originalFee, feeLimit, maxFeePrice, attempts, opts
+func (_m *EvmFeeEstimator) BumpFee(ctx context.Context, originalFee gas.EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []gas.EvmPriorAttempt, opts ...types.Opt) (gas.EvmFee, uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, originalFee, feeLimit, maxFeePrice, attempts)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gas.EvmFee
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) (gas.EvmFee, uint32, error)); ok {
+		return rf(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) gas.EvmFee); ok {
+		r0 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+	} else {
+		r0 = ret.Get(0).(gas.EvmFee)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) uint32); ok {
+		r1 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt, ...types.Opt) error); ok {
+		r2 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BumpToFee provides a mock function with given fields: ctx, target, feeLimit, maxFeePrice, attempts
+func (_m *EvmFeeEstimator) BumpToFee(ctx context.Context, target gas.EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []gas.EvmPriorAttempt) (gas.EvmFee, uint32, error) {
+	ret := _m.Called(ctx, target, feeLimit, maxFeePrice, attempts)
 
 	var r0 gas.EvmFee
 	var r1 uint32
 	var r2 error
 	if rf, ok := ret.Get(0).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) (gas.EvmFee, uint32, error)); ok {
-		return rf(ctx, originalFee, feeLimit, maxFeePrice, attempts)
+		return rf(ctx, target, feeLimit, maxFeePrice, attempts)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) gas.EvmFee); ok {
-		r0 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts)
+		r0 = rf(ctx, target, feeLimit, maxFeePrice, attempts)
 	} else {
 		r0 = ret.Get(0).(gas.EvmFee)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) uint32); ok {
-		r1 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts)
+		r1 = rf(ctx, target, feeLimit, maxFeePrice, attempts)
 	} else {
 		r1 = ret.Get(1).(uint32)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, gas.EvmFee, uint32, *assets.Wei, []gas.EvmPriorAttempt) error); ok {
-		r2 = rf(ctx, originalFee, feeLimit, maxFeePrice, attempts)
+		r2 = rf(ctx, target, feeLimit, maxFeePrice, attempts)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -70,6 +110,72 @@ func (_m *EvmFeeEstimator) Close() error {
 	return r0
 }
 
+// CurrentFees provides a mock function with given fields:
+func (_m *EvmFeeEstimator) CurrentFees() (*assets.Wei, *assets.Wei, bool) {
+	ret := _m.Called()
+
+	var r0 *assets.Wei
+	var r1 *assets.Wei
+	var r2 bool
+	if rf, ok := ret.Get(0).(func() (*assets.Wei, *assets.Wei, bool)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *assets.Wei); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*assets.Wei)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() *assets.Wei); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*assets.Wei)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func() bool); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
+// EstimateGasLimit provides a mock function with given fields: ctx, calldata, feeLimit, opts
+func (_m *EvmFeeEstimator) EstimateGasLimit(ctx context.Context, calldata []byte, feeLimit uint32, opts ...types.Opt) (uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, calldata, feeLimit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, ...types.Opt) (uint32, error)); ok {
+		return rf(ctx, calldata, feeLimit, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, ...types.Opt) uint32); ok {
+		r0 = rf(ctx, calldata, feeLimit, opts...)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, uint32, ...types.Opt) error); ok {
+		r1 = rf(ctx, calldata, feeLimit, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFee provides a mock function with given fields: ctx, calldata, feeLimit, maxFeePrice, opts
 func (_m *EvmFeeEstimator) GetFee(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...types.Opt) (gas.EvmFee, uint32, error) {
 	_va := make([]interface{}, len(opts))
@@ -108,6 +214,110 @@ func (_m *EvmFeeEstimator) GetFee(ctx context.Context, calldata []byte, feeLimit
 	return r0, r1, r2
 }
 
+// GetFeeCandidates provides a mock function with given fields: ctx, calldata, feeLimit, maxFeePrice, opts
+func (_m *EvmFeeEstimator) GetFeeCandidates(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...types.Opt) ([]gas.EvmFee, uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, calldata, feeLimit, maxFeePrice)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []gas.EvmFee
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, *assets.Wei, ...types.Opt) ([]gas.EvmFee, uint32, error)); ok {
+		return rf(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, uint32, *assets.Wei, ...types.Opt) []gas.EvmFee); ok {
+		r0 = rf(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gas.EvmFee)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, uint32, *assets.Wei, ...types.Opt) uint32); ok {
+		r1 = rf(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, uint32, *assets.Wei, ...types.Opt) error); ok {
+		r2 = rf(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFeeForType provides a mock function with given fields: ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts
+func (_m *EvmFeeEstimator) GetFeeForType(ctx context.Context, txType int, toAddress common.Address, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...types.Opt) (gas.EvmFee, uint32, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, txType, toAddress, calldata, feeLimit, maxFeePrice)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 gas.EvmFee
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, common.Address, []byte, uint32, *assets.Wei, ...types.Opt) (gas.EvmFee, uint32, error)); ok {
+		return rf(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, common.Address, []byte, uint32, *assets.Wei, ...types.Opt) gas.EvmFee); ok {
+		r0 = rf(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r0 = ret.Get(0).(gas.EvmFee)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, common.Address, []byte, uint32, *assets.Wei, ...types.Opt) uint32); ok {
+		r1 = rf(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, common.Address, []byte, uint32, *assets.Wei, ...types.Opt) error); ok {
+		r2 = rf(ctx, txType, toAddress, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFeeHistory provides a mock function with given fields: ctx, blockCount, percentiles
+func (_m *EvmFeeEstimator) GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*gas.FeeHistory, error) {
+	ret := _m.Called(ctx, blockCount, percentiles)
+
+	var r0 *gas.FeeHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []float64) (*gas.FeeHistory, error)); ok {
+		return rf(ctx, blockCount, percentiles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []float64) *gas.FeeHistory); ok {
+		r0 = rf(ctx, blockCount, percentiles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gas.FeeHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []float64) error); ok {
+		r1 = rf(ctx, blockCount, percentiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMaxCost provides a mock function with given fields: ctx, amount, calldata, feeLimit, maxFeePrice, opts
 func (_m *EvmFeeEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...types.Opt) (*big.Int, error) {
 	_va := make([]interface{}, len(opts))
@@ -141,6 +351,48 @@ func (_m *EvmFeeEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, ca
 	return r0, r1
 }
 
+// GetMaxCostBreakdown provides a mock function with given fields: ctx, amount, calldata, feeLimit, maxFeePrice, opts
+func (_m *EvmFeeEstimator) GetMaxCostBreakdown(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...types.Opt) (*big.Int, *big.Int, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, amount, calldata, feeLimit, maxFeePrice)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *big.Int
+	var r1 *big.Int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, assets.Eth, []byte, uint32, *assets.Wei, ...types.Opt) (*big.Int, *big.Int, error)); ok {
+		return rf(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, assets.Eth, []byte, uint32, *assets.Wei, ...types.Opt) *big.Int); ok {
+		r0 = rf(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, assets.Eth, []byte, uint32, *assets.Wei, ...types.Opt) *big.Int); ok {
+		r1 = rf(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, assets.Eth, []byte, uint32, *assets.Wei, ...types.Opt) error); ok {
+		r2 = rf(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // HealthReport provides a mock function with given fields:
 func (_m *EvmFeeEstimator) HealthReport() map[string]error {
 	ret := _m.Called()
@@ -157,6 +409,20 @@ func (_m *EvmFeeEstimator) HealthReport() map[string]error {
 	return r0
 }
 
+// IsWarm provides a mock function with given fields:
+func (_m *EvmFeeEstimator) IsWarm() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // L1Oracle provides a mock function with given fields:
 func (_m *EvmFeeEstimator) L1Oracle() rollups.L1Oracle {
 	ret := _m.Called()
@@ -192,6 +458,11 @@ func (_m *EvmFeeEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes
 	_m.Called(ctx, head)
 }
 
+// OnReorg provides a mock function with given fields: ctx, head, commonAncestor
+func (_m *EvmFeeEstimator) OnReorg(ctx context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head) {
+	_m.Called(ctx, head, commonAncestor)
+}
+
 // Ready provides a mock function with given fields:
 func (_m *EvmFeeEstimator) Ready() error {
 	ret := _m.Called()
@@ -220,6 +491,34 @@ func (_m *EvmFeeEstimator) Start(_a0 context.Context) error {
 	return r0
 }
 
+// SupportsEIP1559 provides a mock function with given fields:
+func (_m *EvmFeeEstimator) SupportsEIP1559() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Warm provides a mock function with given fields: ctx, headsNeeded
+func (_m *EvmFeeEstimator) Warm(ctx context.Context, headsNeeded int) error {
+	ret := _m.Called(ctx, headsNeeded)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, headsNeeded)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEvmFeeEstimator creates a new instance of EvmFeeEstimator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEvmFeeEstimator(t interface {
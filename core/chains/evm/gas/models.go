@@ -2,11 +2,19 @@ package gas
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
@@ -35,10 +43,81 @@ type EvmFeeEstimator interface {
 	// L1Oracle returns the L1 gas price oracle only if the chain has one, e.g. OP stack L2s and Arbitrum.
 	L1Oracle() rollups.L1Oracle
 	GetFee(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error)
-	BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error)
+	// GetFeeForType returns a fee shaped for the given tx type (0x0 legacy, 0x2 dynamic), or an error if the estimator cannot serve that type.
+	// toAddress is the destination of the tx, which address-aware estimators may use to refine their estimate.
+	GetFeeForType(ctx context.Context, txType int, toAddress common.Address, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error)
+	BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error)
+	// BumpToFee bumps to an exact target fee, e.g. one chosen by an operator for a stuck tx, instead of computing the
+	// bump. It errors with ErrBumpTargetTooLow unless the target is above the fees of all prior attempts, and with
+	// ErrBumpFeeExceedsLimit if it is above maxFeePrice. The fee limit is returned unchanged.
+	BumpToFee(ctx context.Context, target EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error)
+	// GetFeeCandidates returns several fees derived from a single estimate, ordered from cheapest to most expensive:
+	// conservative, normal (the fee GetFee would return) and aggressive, see FeeCandidatePercents. No candidate
+	// exceeds maxFeePrice. All candidates share the returned chain specific fee limit.
+	GetFeeCandidates(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (candidates []EvmFee, chainSpecificFeeLimit uint32, err error)
+	// EstimateGasLimit returns the fee limit GetFee would return for the calldata, without estimating a price
+	EstimateGasLimit(ctx context.Context, calldata []byte, feeLimit uint32, opts ...feetypes.Opt) (chainSpecificFeeLimit uint32, err error)
 
 	// GetMaxCost returns the total value = max price x fee units + transferred value
 	GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (*big.Int, error)
+	// GetMaxCostBreakdown returns GetMaxCost as l2, and in addition l1Min, a lower bound on the L1 data availability
+	// fee for the calldata, which GetMaxCost does not include: the L1 gas price x the calldata gas of the calldata alone.
+	// It leaves out the signed tx envelope and any rollup specific overhead and scalar (e.g. the OP stack's getL1Fee),
+	// so the actual L1 fee is higher. l1Min is zero for chains without an L1 oracle.
+	GetMaxCostBreakdown(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (l2 *big.Int, l1Min *big.Int, err error)
+
+	// GetFeeHistory returns the base fee and the given reward percentiles (0-100) of the most recent blockCount blocks
+	// known to the estimator. Estimators without history data return ErrNotSupported.
+	GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*FeeHistory, error)
+
+	// OnReorg is called instead of only OnNewLongestChain when head replaces blocks the estimator has already seen.
+	// commonAncestor is the latest block shared by the old and new chains, or nil if it is unknown.
+	OnReorg(ctx context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head)
+
+	// SupportsEIP1559 returns true if the estimator can serve dynamic fees for type 0x2 txs
+	SupportsEIP1559() bool
+
+	// CurrentFees returns the latest base fee and suggested tip cap computed by the estimator, e.g. for dashboards.
+	// ok is false until the estimator has seen its first head, or if it does not track these values.
+	CurrentFees() (baseFee *assets.Wei, suggestedTip *assets.Wei, ok bool)
+
+	// Warm blocks until the estimator has ingested headsNeeded heads, or returns an error when ctx is done first.
+	// Warm returns straight away if enough heads have already been ingested.
+	Warm(ctx context.Context, headsNeeded int) error
+	// IsWarm returns true if the estimator has ingested the number of heads required by the latest call to Warm, or at
+	// least one head if Warm has not been called.
+	IsWarm() bool
+}
+
+// ErrNotSupported is returned by estimators that cannot serve an optional request, e.g. GetFeeHistory
+var ErrNotSupported = errors.New("not supported by this estimator")
+
+// ErrBumpTargetTooLow is returned by BumpToFee when the target fee is not above the fee of a prior attempt
+var ErrBumpTargetTooLow = errors.New("bump target fee is not above prior attempts")
+
+// FeeHistory holds per-block fee data, ordered from oldest to newest block
+type FeeHistory struct {
+	// OldestBlock is the number of the first block in the history
+	OldestBlock int64
+	// BaseFees is the base fee of each block, nil for pre-EIP1559 blocks
+	BaseFees []*assets.Wei
+	// Rewards holds the effective tip cap at each requested percentile for each block
+	Rewards [][]*assets.Wei
+}
+
+// feeHistoryProvider is implemented by estimators that keep block history
+type feeHistoryProvider interface {
+	GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*FeeHistory, error)
+}
+
+// currentFeesProvider is implemented by estimators that track the latest base fee and tip cap
+type currentFeesProvider interface {
+	CurrentFees() (baseFee *assets.Wei, suggestedTip *assets.Wei, ok bool)
+}
+
+// reorgHandler is implemented by estimators that must discard state on reorgs
+type reorgHandler interface {
+	OnReorg(ctx context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head)
 }
 
 // NewEstimator returns the estimator for a given config
@@ -63,27 +142,18 @@ func NewEstimator(lggr logger.Logger, ethClient evmclient.Client, cfg Config, ge
 		"tipCapMin", geCfg.TipCapMin(),
 		"priceMax", geCfg.PriceMax(),
 		"priceMin", geCfg.PriceMin(),
+		"staleFeeGracePeriod", geCfg.StaleFeeGracePeriod(),
 	)
-	df := geCfg.EIP1559DynamicFees()
-
-	// create l1Oracle only if it is supported for the chain
-	var l1Oracle rollups.L1Oracle
-	if rollups.IsRollupWithL1Support(cfg.ChainType()) {
-		l1Oracle = rollups.NewL1GasPriceOracle(lggr, ethClient, cfg.ChainType())
-	}
-	switch s {
-	case "Arbitrum":
-		return NewWrappedEvmEstimator(NewArbitrumEstimator(lggr, geCfg, ethClient, ethClient), df, l1Oracle)
-	case "BlockHistory":
-		return NewWrappedEvmEstimator(NewBlockHistoryEstimator(lggr, ethClient, cfg, geCfg, bh, *ethClient.ConfiguredChainID()), df, l1Oracle)
-	case "FixedPrice":
-		return NewWrappedEvmEstimator(NewFixedPriceEstimator(geCfg, bh, lggr), df, l1Oracle)
-	case "L2Suggested", "SuggestedPrice":
-		return NewWrappedEvmEstimator(NewSuggestedPriceEstimator(lggr, ethClient), df, l1Oracle)
-	default:
+	e, err := NewEstimatorByName(s, lggr, ethClient, cfg, geCfg)
+	if err != nil {
 		lggr.Warnf("GasEstimator: unrecognised mode '%s', falling back to FixedPriceEstimator", s)
-		return NewWrappedEvmEstimator(NewFixedPriceEstimator(geCfg, bh, lggr), df, l1Oracle)
+		e, _ = NewEstimatorByName("FixedPrice", lggr, ethClient, cfg, geCfg)
+	}
+	if w, ok := e.(*WrappedEvmEstimator); ok {
+		w.SetStaleFeeGracePeriod(geCfg.StaleFeeGracePeriod())
+		w.SetMinFees(geCfg.PriceMin(), geCfg.TipCapMin())
 	}
+	return e
 }
 
 // DynamicFee encompasses both FeeCap and TipCap for EIP1559 transactions
@@ -119,13 +189,17 @@ type EvmEstimator interface {
 	BumpLegacyGas(ctx context.Context, originalGasPrice *assets.Wei, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (bumpedGasPrice *assets.Wei, chainSpecificGasLimit uint32, err error)
 	// GetDynamicFee Calculates initial gas fee for gas for EIP1559 transactions
 	// maxGasPriceWei parameter is the highest possible gas fee cap that the function will return
-	GetDynamicFee(ctx context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei) (fee DynamicFee, chainSpecificGasLimit uint32, err error)
+	// WithBaseFee in opts overrides the base fee the estimator would otherwise fetch from the chain
+	GetDynamicFee(ctx context.Context, gasLimit uint32, maxGasPriceWei *assets.Wei, opts ...feetypes.Opt) (fee DynamicFee, chainSpecificGasLimit uint32, err error)
 	// BumpDynamicFee Increases gas price and/or limit for non-EIP1559 transactions
 	// if the bumped gas fee or tip caps are greater than maxGasPriceWei, the method returns an error
 	// attempts must:
 	//   - be sorted in order from highest price to lowest price
 	//   - all be of transaction type 0x2
-	BumpDynamicFee(ctx context.Context, original DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error)
+	// WithBaseFee in opts overrides the base fee the estimator would otherwise fetch from the chain
+	BumpDynamicFee(ctx context.Context, original DynamicFee, gasLimit uint32, maxGasPriceWei *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error)
+	// EstimateGasLimit calculates the chain specific gas limit only, skipping price estimation
+	EstimateGasLimit(ctx context.Context, calldata []byte, gasLimit uint32, opts ...feetypes.Opt) (chainSpecificGasLimit uint32, err error)
 }
 
 var _ feetypes.Fee = (*EvmFee)(nil)
@@ -137,22 +211,298 @@ type EvmFee struct {
 	// dynamic/EIP1559 fees
 	DynamicFeeCap *assets.Wei
 	DynamicTipCap *assets.Wei
+
+	// Stale is set when the estimator failed and this fee was served from its cache instead
+	Stale bool
 }
 
+// String returns the fee labelled with the tx type it implies, omitting nil components
 func (fee EvmFee) String() string {
-	return fmt.Sprintf("{Legacy: %s, DynamicFeeCap: %s, DynamicTipCap: %s}", fee.Legacy, fee.DynamicFeeCap, fee.DynamicTipCap)
+	var parts []string
+	if fee.Legacy != nil {
+		parts = append(parts, "Legacy: "+fee.Legacy.String())
+	}
+	if fee.DynamicFeeCap != nil {
+		parts = append(parts, "DynamicFeeCap: "+fee.DynamicFeeCap.String())
+	}
+	if fee.DynamicTipCap != nil {
+		parts = append(parts, "DynamicTipCap: "+fee.DynamicTipCap.String())
+	}
+	if fee.Stale {
+		parts = append(parts, "Stale: true")
+	}
+	return fmt.Sprintf("EvmFee(%s){%s}", fee.feeType(), strings.Join(parts, ", "))
+}
+
+// LoggerFields returns the non-nil fee components as key/value pairs for structured logging
+func (fee EvmFee) LoggerFields() []interface{} {
+	fields := []interface{}{"feeType", fee.feeType()}
+	if fee.Legacy != nil {
+		fields = append(fields, "gasPrice", fee.Legacy)
+	}
+	if fee.DynamicFeeCap != nil {
+		fields = append(fields, "gasFeeCap", fee.DynamicFeeCap)
+	}
+	if fee.DynamicTipCap != nil {
+		fields = append(fields, "gasTipCap", fee.DynamicTipCap)
+	}
+	if fee.Stale {
+		fields = append(fields, "feeStale", true)
+	}
+	return fields
+}
+
+// feeType describes the tx type implied by which fee components are set
+func (fee EvmFee) feeType() string {
+	dynamic := fee.DynamicFeeCap != nil || fee.DynamicTipCap != nil
+	switch {
+	case fee.Legacy != nil && dynamic:
+		return "mixed"
+	case fee.Legacy != nil:
+		return "legacy"
+	case dynamic:
+		return "dynamic"
+	default:
+		return "empty"
+	}
+}
+
+// Clone returns a copy of the fee that shares no *assets.Wei with the original
+func (fee EvmFee) Clone() EvmFee {
+	fee.Legacy = cloneWei(fee.Legacy)
+	fee.DynamicFeeCap = cloneWei(fee.DynamicFeeCap)
+	fee.DynamicTipCap = cloneWei(fee.DynamicTipCap)
+	return fee
+}
+
+// MarshalBinary encodes the fee deterministically: a byte flagging which components are set and whether the fee is
+// stale, followed by each set component as a length-prefixed big-endian integer
+func (fee EvmFee) MarshalBinary() ([]byte, error) {
+	var flags byte
+	b := []byte{0}
+	for i, w := range []*assets.Wei{fee.Legacy, fee.DynamicFeeCap, fee.DynamicTipCap} {
+		if w == nil {
+			continue
+		}
+		if w.IsNegative() {
+			return nil, errors.Errorf("cannot marshal negative fee component %s", w)
+		}
+		flags |= 1 << i
+		v := w.ToInt().Bytes()
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	if fee.Stale {
+		flags |= 1 << 3
+	}
+	b[0] = flags
+	return b, nil
+}
+
+// UnmarshalBinary decodes a fee encoded by MarshalBinary
+func (fee *EvmFee) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("cannot unmarshal fee: empty data")
+	}
+	flags := data[0]
+	if flags>>4 != 0 {
+		return errors.Errorf("cannot unmarshal fee: unknown flags %08b", flags)
+	}
+	data = data[1:]
+	var decoded EvmFee
+	for i, w := range []**assets.Wei{&decoded.Legacy, &decoded.DynamicFeeCap, &decoded.DynamicTipCap} {
+		if flags&(1<<i) == 0 {
+			continue
+		}
+		if len(data) < 4 || uint64(len(data)-4) < uint64(binary.BigEndian.Uint32(data)) {
+			return errors.New("cannot unmarshal fee: data too short")
+		}
+		n := binary.BigEndian.Uint32(data)
+		*w = assets.NewWei(new(big.Int).SetBytes(data[4 : 4+n]))
+		data = data[4+n:]
+	}
+	if len(data) != 0 {
+		return errors.Errorf("cannot unmarshal fee: %d trailing bytes", len(data))
+	}
+	decoded.Stale = flags&(1<<3) != 0
+	*fee = decoded
+	return nil
 }
 
+// evmFeeJSON is the JSON form of EvmFee. Only set components are emitted, alongside the fee type they imply.
+type evmFeeJSON struct {
+	Type      string      `json:"type"`
+	GasPrice  *assets.Wei `json:"gasPrice,omitempty"`
+	GasFeeCap *assets.Wei `json:"gasFeeCap,omitempty"`
+	GasTipCap *assets.Wei `json:"gasTipCap,omitempty"`
+	Stale     bool        `json:"stale,omitempty"`
+}
+
+// MarshalJSON encodes the fee with a "type" discriminator (legacy, dynamic, mixed or empty), omitting nil components
+func (fee EvmFee) MarshalJSON() ([]byte, error) {
+	return json.Marshal(evmFeeJSON{
+		Type:      fee.feeType(),
+		GasPrice:  fee.Legacy,
+		GasFeeCap: fee.DynamicFeeCap,
+		GasTipCap: fee.DynamicTipCap,
+		Stale:     fee.Stale,
+	})
+}
+
+// UnmarshalJSON decodes a fee encoded by MarshalJSON, rejecting fees whose components do not match their type
+func (fee *EvmFee) UnmarshalJSON(data []byte) error {
+	var enc evmFeeJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return errors.Wrap(err, "cannot unmarshal fee")
+	}
+	decoded := EvmFee{Legacy: enc.GasPrice, DynamicFeeCap: enc.GasFeeCap, DynamicTipCap: enc.GasTipCap, Stale: enc.Stale}
+	if t := decoded.feeType(); t != enc.Type {
+		return errors.Errorf("cannot unmarshal fee: type %q does not match its components, which imply %q", enc.Type, t)
+	}
+	*fee = decoded
+	return nil
+}
+
+func cloneWei(w *assets.Wei) *assets.Wei {
+	if w == nil {
+		return nil
+	}
+	return assets.NewWei(new(big.Int).Set(w.ToInt()))
+}
+
+// NewLegacyFee returns a legacy fee with the given gas price
+func NewLegacyFee(price *assets.Wei) EvmFee {
+	return EvmFee{Legacy: price}
+}
+
+// NewDynamicFee returns a dynamic fee with the given fee cap and tip cap
+func NewDynamicFee(feeCap, tipCap *assets.Wei) EvmFee {
+	return EvmFee{DynamicFeeCap: feeCap, DynamicTipCap: tipCap}
+}
+
+// ValidLegacy returns true if the fee can be used to build a legacy tx
+func (fee EvmFee) ValidLegacy() bool {
+	return fee.Legacy != nil
+}
+
+// ValidDynamic returns true if the fee can be used to build a dynamic fee tx
 func (fee EvmFee) ValidDynamic() bool {
 	return fee.DynamicFeeCap != nil && fee.DynamicTipCap != nil
 }
 
+// Scale returns a copy of the fee with every non-nil component multiplied by num/denom, resolving fractional wei
+// according to rounding. Panics if denom is zero.
+func (fee EvmFee) Scale(num, denom int64, rounding Rounding) EvmFee {
+	scale := func(w *assets.Wei) *assets.Wei {
+		if w == nil {
+			return nil
+		}
+		return scaleWei(w, num, denom, rounding)
+	}
+	fee.Legacy = scale(fee.Legacy)
+	fee.DynamicFeeCap = scale(fee.DynamicFeeCap)
+	fee.DynamicTipCap = scale(fee.DynamicTipCap)
+	return fee
+}
+
+// Rounding controls how fractional wei are resolved when scaling a fee
+type Rounding int
+
+const (
+	// RoundDown truncates fractional wei
+	RoundDown Rounding = iota
+	// RoundUp rounds fractional wei up, so a scaled fee never falls below its target
+	RoundUp
+	// RoundNearest rounds fractional wei to the nearest wei, with halves rounded up
+	RoundNearest
+)
+
+// scaleWei returns w * num / denom for non-negative values, resolving fractional wei according to rounding
+func scaleWei(w *assets.Wei, num, denom int64, rounding Rounding) *assets.Wei {
+	d := big.NewInt(denom)
+	q, r := new(big.Int).QuoRem(new(big.Int).Mul(w.ToInt(), big.NewInt(num)), d, new(big.Int))
+	switch {
+	case r.Sign() == 0:
+	case rounding == RoundUp:
+		q.Add(q, big.NewInt(1))
+	case rounding == RoundNearest && r.Lsh(r, 1).Cmp(d) >= 0:
+		q.Add(q, big.NewInt(1))
+	}
+	return assets.NewWei(q)
+}
+
+// addPercentage returns w increased by percentage, resolving fractional wei according to rounding
+func addPercentage(w *assets.Wei, percentage uint16, rounding Rounding) *assets.Wei {
+	return scaleWei(w, 100+int64(percentage), 100, rounding)
+}
+
+// Cmp compares the max price per gas of two fees, breaking ties on the tip, and returns -1, 0 or +1.
+// A legacy gas price is treated as both the fee cap and the tip cap, since a legacy tx pays its whole gas price
+// on top of the base fee, so legacy and dynamic fees can be compared with each other. Dynamic components take
+// precedence over a legacy price when a fee has both, and nil components compare as zero.
+func (fee EvmFee) Cmp(other EvmFee) int {
+	feeCap, tipCap := fee.effectiveCaps()
+	otherFeeCap, otherTipCap := other.effectiveCaps()
+	if c := feeCap.Cmp(otherFeeCap); c != 0 {
+		return c
+	}
+	return tipCap.Cmp(otherTipCap)
+}
+
+// GreaterThan returns true if the fee compares greater than other, as defined by Cmp
+func (fee EvmFee) GreaterThan(other EvmFee) bool {
+	return fee.Cmp(other) > 0
+}
+
+// effectiveCaps returns the fee cap and tip cap the fee amounts to, with nil components as zero
+func (fee EvmFee) effectiveCaps() (feeCap, tipCap *assets.Wei) {
+	orZero := func(w *assets.Wei) *assets.Wei {
+		if w == nil {
+			return assets.NewWeiI(0)
+		}
+		return w
+	}
+	if fee.DynamicFeeCap == nil && fee.DynamicTipCap == nil {
+		return orZero(fee.Legacy), orZero(fee.Legacy)
+	}
+	return orZero(fee.DynamicFeeCap), orZero(fee.DynamicTipCap)
+}
+
 // WrappedEvmEstimator provides a struct that wraps the EVM specific dynamic and legacy estimators into one estimator that conforms to the generic FeeEstimator
 type WrappedEvmEstimator struct {
 	services.StateMachine
 	EvmEstimator
 	EIP1559Enabled bool
 	l1Oracle       rollups.L1Oracle
+
+	// priceMin and tipCapMin are the floors for fee candidates scaled below the estimate, nil for no floor
+	priceMin  *assets.Wei
+	tipCapMin *assets.Wei
+
+	// staleFeeGracePeriod is how long the last good fee may be served while the underlying estimator is failing
+	staleFeeGracePeriod time.Duration
+	feeCacheMu          sync.RWMutex
+	feeCache            map[feeCacheKey]cachedFee
+
+	warmMu sync.Mutex
+	// headsIngested is the number of heads passed to OnNewLongestChain
+	headsIngested int
+	// warmHeads is the number of heads IsWarm requires, set by Warm
+	warmHeads int
+	// headIngested is closed and replaced on each head, to wake up callers of Warm
+	headIngested chan struct{}
+}
+
+type feeCacheKey struct {
+	txType       int
+	feeLimit     uint32
+	calldataHash [sha256.Size]byte
+}
+
+type cachedFee struct {
+	fee                   EvmFee
+	chainSpecificFeeLimit uint32
+	estimatedAt           time.Time
 }
 
 var _ EvmFeeEstimator = (*WrappedEvmEstimator)(nil)
@@ -162,9 +512,24 @@ func NewWrappedEvmEstimator(e EvmEstimator, eip1559Enabled bool, l1Oracle rollup
 		EvmEstimator:   e,
 		EIP1559Enabled: eip1559Enabled,
 		l1Oracle:       l1Oracle,
+		warmHeads:      1,
+		headIngested:   make(chan struct{}),
 	}
 }
 
+// SetStaleFeeGracePeriod enables serving the last successfully estimated fee, flagged as Stale, when the
+// underlying estimator errors and that fee is no older than gracePeriod. Zero disables the cache.
+// Must be called before the estimator is used.
+func (e *WrappedEvmEstimator) SetStaleFeeGracePeriod(gracePeriod time.Duration) {
+	e.staleFeeGracePeriod = gracePeriod
+}
+
+// SetMinFees sets the min gas price and tip cap that GetFeeCandidates never goes below.
+// Must be called before the estimator is used.
+func (e *WrappedEvmEstimator) SetMinFees(priceMin, tipCapMin *assets.Wei) {
+	e.priceMin, e.tipCapMin = priceMin, tipCapMin
+}
+
 func (e *WrappedEvmEstimator) Name() string {
 	return fmt.Sprintf("WrappedEvmEstimator(%s)", e.EvmEstimator.Name())
 }
@@ -229,16 +594,127 @@ func (e *WrappedEvmEstimator) L1Oracle() rollups.L1Oracle {
 func (e *WrappedEvmEstimator) GetFee(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
 	// get dynamic fee
 	if e.EIP1559Enabled {
+		return e.GetFeeForType(ctx, 0x2, common.Address{}, calldata, feeLimit, maxFeePrice, opts...)
+	}
+
+	// get legacy fee
+	return e.GetFeeForType(ctx, 0x0, common.Address{}, calldata, feeLimit, maxFeePrice, opts...)
+}
+
+// FeeCandidatePercents are the percentages of the estimated fee returned by GetFeeCandidates, in order: conservative,
+// normal and aggressive
+var FeeCandidatePercents = []int64{80, 100, 125}
+
+// GetFeeCandidates scales the fee from GetFee by each of FeeCandidatePercents, rounding up. Candidates are raised to
+// the min gas price and tip cap set by SetMinFees, with the fee cap kept at or above the tip cap, and capped at
+// maxFeePrice.
+func (e *WrappedEvmEstimator) GetFeeCandidates(ctx context.Context, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (candidates []EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err := e.GetFee(ctx, calldata, feeLimit, maxFeePrice, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return feeCandidates(fee, e.priceMin, e.tipCapMin, maxFeePrice), chainSpecificFeeLimit, nil
+}
+
+func feeCandidates(fee EvmFee, priceMin, tipCapMin, maxFeePrice *assets.Wei) []EvmFee {
+	atLeast := func(w, floor *assets.Wei) *assets.Wei {
+		if w == nil || floor == nil {
+			return w
+		}
+		return assets.WeiMax(w, floor)
+	}
+	capped := func(w *assets.Wei) *assets.Wei {
+		if w == nil || maxFeePrice == nil {
+			return w
+		}
+		return assets.WeiMin(w, maxFeePrice)
+	}
+	candidates := make([]EvmFee, 0, len(FeeCandidatePercents))
+	for _, pct := range FeeCandidatePercents {
+		c := fee.Scale(pct, 100, RoundUp)
+		c.Legacy = atLeast(c.Legacy, priceMin)
+		c.DynamicTipCap = atLeast(c.DynamicTipCap, tipCapMin)
+		c.DynamicFeeCap = atLeast(c.DynamicFeeCap, c.DynamicTipCap)
+		c.Legacy, c.DynamicFeeCap, c.DynamicTipCap = capped(c.Legacy), capped(c.DynamicFeeCap), capped(c.DynamicTipCap)
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// GetFeeForType ignores toAddress, since none of the wrapped EvmEstimators are address-aware.
+// Fees estimated with opts carrying a value, e.g. WithBaseFee, are neither cached nor served from the cache.
+func (e *WrappedEvmEstimator) GetFeeForType(ctx context.Context, txType int, toAddress common.Address, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	fee, chainSpecificFeeLimit, err = e.getFeeForType(ctx, txType, calldata, feeLimit, maxFeePrice, opts...)
+	if e.staleFeeGracePeriod <= 0 || feetypes.HasValueOpt(opts) {
+		return
+	}
+	key := feeCacheKey{txType: txType, feeLimit: feeLimit, calldataHash: sha256.Sum256(calldata)}
+	if err == nil {
+		e.cacheFee(key, fee, chainSpecificFeeLimit)
+		return
+	}
+	if cached, ok := e.cachedFee(key, maxFeePrice); ok {
+		return cached.fee, cached.chainSpecificFeeLimit, nil
+	}
+	return
+}
+
+func (e *WrappedEvmEstimator) cacheFee(key feeCacheKey, fee EvmFee, chainSpecificFeeLimit uint32) {
+	now := time.Now()
+	e.feeCacheMu.Lock()
+	defer e.feeCacheMu.Unlock()
+	if e.feeCache == nil {
+		e.feeCache = make(map[feeCacheKey]cachedFee)
+	}
+	// prune entries that can no longer be served so the cache does not grow unbounded
+	for k, c := range e.feeCache {
+		if now.Sub(c.estimatedAt) > e.staleFeeGracePeriod {
+			delete(e.feeCache, k)
+		}
+	}
+	e.feeCache[key] = cachedFee{fee: fee, chainSpecificFeeLimit: chainSpecificFeeLimit, estimatedAt: now}
+}
+
+// cachedFee returns the cached fee for key, flagged as stale, if it is within the grace period and does not exceed maxFeePrice
+func (e *WrappedEvmEstimator) cachedFee(key feeCacheKey, maxFeePrice *assets.Wei) (c cachedFee, ok bool) {
+	e.feeCacheMu.RLock()
+	defer e.feeCacheMu.RUnlock()
+	c, ok = e.feeCache[key]
+	if !ok || time.Since(c.estimatedAt) > e.staleFeeGracePeriod {
+		return c, false
+	}
+	price := c.fee.Legacy
+	if key.txType == 0x2 {
+		price = c.fee.DynamicFeeCap
+	}
+	if maxFeePrice != nil && price.Cmp(maxFeePrice) > 0 {
+		return c, false
+	}
+	c.fee.Stale = true
+	return c, true
+}
+
+func (e *WrappedEvmEstimator) getFeeForType(ctx context.Context, txType int, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (fee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	switch txType {
+	case 0x0:
+		fee.Legacy, chainSpecificFeeLimit, err = e.EvmEstimator.GetLegacyGas(ctx, calldata, feeLimit, maxFeePrice, opts...)
+		return
+	case 0x2:
+		if !e.EIP1559Enabled {
+			return fee, 0, errors.New("cannot estimate fee for type 0x2 transaction: EIP1559 dynamic fees are not enabled")
+		}
 		var dynamicFee DynamicFee
-		dynamicFee, chainSpecificFeeLimit, err = e.EvmEstimator.GetDynamicFee(ctx, feeLimit, maxFeePrice)
+		dynamicFee, chainSpecificFeeLimit, err = e.EvmEstimator.GetDynamicFee(ctx, feeLimit, maxFeePrice, opts...)
 		fee.DynamicFeeCap = dynamicFee.FeeCap
 		fee.DynamicTipCap = dynamicFee.TipCap
 		return
+	default:
+		return fee, 0, errors.Errorf("cannot estimate fee for unrecognised transaction type 0x%x", txType)
 	}
+}
 
-	// get legacy fee
-	fee.Legacy, chainSpecificFeeLimit, err = e.EvmEstimator.GetLegacyGas(ctx, calldata, feeLimit, maxFeePrice, opts...)
-	return
+func (e *WrappedEvmEstimator) EstimateGasLimit(ctx context.Context, calldata []byte, feeLimit uint32, opts ...feetypes.Opt) (chainSpecificFeeLimit uint32, err error) {
+	return e.EvmEstimator.EstimateGasLimit(ctx, calldata, feeLimit, opts...)
 }
 
 func (e *WrappedEvmEstimator) GetMaxCost(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (*big.Int, error) {
@@ -259,9 +735,102 @@ func (e *WrappedEvmEstimator) GetMaxCost(ctx context.Context, amount assets.Eth,
 	return amountWithFees, nil
 }
 
-func (e *WrappedEvmEstimator) BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+// GetMaxCostBreakdown only accounts for the calldata in l1Min, see EvmFeeEstimator
+func (e *WrappedEvmEstimator) GetMaxCostBreakdown(ctx context.Context, amount assets.Eth, calldata []byte, feeLimit uint32, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (l2 *big.Int, l1Min *big.Int, err error) {
+	l2, err = e.GetMaxCost(ctx, amount, calldata, feeLimit, maxFeePrice, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.l1Oracle == nil {
+		return l2, big.NewInt(0), nil
+	}
+	l1GasPrice, err := e.l1Oracle.GasPrice(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get L1 gas price")
+	}
+	l1Min = new(big.Int).Mul(l1GasPrice.ToInt(), new(big.Int).SetUint64(CalldataGas(calldata)))
+	return l2, l1Min, nil
+}
+
+// CalldataGas returns the gas charged for posting calldata: 16 per non-zero byte and 4 per zero byte
+func CalldataGas(calldata []byte) uint64 {
+	var total uint64
+	for _, b := range calldata {
+		if b == 0 {
+			total += params.TxDataZeroGas
+		} else {
+			total += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	return total
+}
+
+// OnNewLongestChain passes the head on to the underlying estimator, and counts it towards warming up
+func (e *WrappedEvmEstimator) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	e.EvmEstimator.OnNewLongestChain(ctx, head)
+	e.warmMu.Lock()
+	defer e.warmMu.Unlock()
+	e.headsIngested++
+	close(e.headIngested)
+	e.headIngested = make(chan struct{})
+}
+
+func (e *WrappedEvmEstimator) Warm(ctx context.Context, headsNeeded int) error {
+	e.warmMu.Lock()
+	e.warmHeads = headsNeeded
+	e.warmMu.Unlock()
+	for {
+		e.warmMu.Lock()
+		ingested, headIngested := e.headsIngested, e.headIngested
+		e.warmMu.Unlock()
+		if ingested >= headsNeeded {
+			return nil
+		}
+		select {
+		case <-headIngested:
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "%s ingested %d of %d heads needed to warm up", e.Name(), ingested, headsNeeded)
+		}
+	}
+}
+
+func (e *WrappedEvmEstimator) IsWarm() bool {
+	e.warmMu.Lock()
+	defer e.warmMu.Unlock()
+	return e.headsIngested >= e.warmHeads
+}
+
+// OnReorg passes the reorg on to the underlying estimator if it keeps state that a reorg invalidates
+func (e *WrappedEvmEstimator) OnReorg(ctx context.Context, head *evmtypes.Head, commonAncestor *evmtypes.Head) {
+	if h, ok := e.EvmEstimator.(reorgHandler); ok {
+		h.OnReorg(ctx, head, commonAncestor)
+	}
+}
+
+// SupportsEIP1559 returns true if the estimator was created with EIP-1559 dynamic fees enabled
+func (e *WrappedEvmEstimator) SupportsEIP1559() bool {
+	return e.EIP1559Enabled
+}
+
+// CurrentFees returns the latest base fee and tip cap from the underlying estimator, if it tracks them
+func (e *WrappedEvmEstimator) CurrentFees() (baseFee *assets.Wei, suggestedTip *assets.Wei, ok bool) {
+	if p, isProvider := e.EvmEstimator.(currentFeesProvider); isProvider {
+		return p.CurrentFees()
+	}
+	return nil, nil, false
+}
+
+// GetFeeHistory returns fee history from the underlying estimator, or ErrNotSupported if it does not keep block history
+func (e *WrappedEvmEstimator) GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*FeeHistory, error) {
+	if p, ok := e.EvmEstimator.(feeHistoryProvider); ok {
+		return p.GetFeeHistory(ctx, blockCount, percentiles)
+	}
+	return nil, errors.Wrapf(ErrNotSupported, "%s does not provide fee history", e.EvmEstimator.Name())
+}
+
+func (e *WrappedEvmEstimator) BumpFee(ctx context.Context, originalFee EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt, opts ...feetypes.Opt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
 	// validate only 1 fee type is present
-	if (!originalFee.ValidDynamic() && originalFee.Legacy == nil) || (originalFee.ValidDynamic() && originalFee.Legacy != nil) {
+	if (!originalFee.ValidDynamic() && !originalFee.ValidLegacy()) || (originalFee.ValidDynamic() && originalFee.ValidLegacy()) {
 		err = errors.New("only one dynamic or legacy fee can be defined")
 		return
 	}
@@ -274,7 +843,7 @@ func (e *WrappedEvmEstimator) BumpFee(ctx context.Context, originalFee EvmFee, f
 			DynamicFee{
 				TipCap: originalFee.DynamicTipCap,
 				FeeCap: originalFee.DynamicFeeCap,
-			}, feeLimit, maxFeePrice, attempts)
+			}, feeLimit, maxFeePrice, attempts, opts...)
 		bumpedFee.DynamicFeeCap = bumpedDynamic.FeeCap
 		bumpedFee.DynamicTipCap = bumpedDynamic.TipCap
 		return
@@ -285,6 +854,55 @@ func (e *WrappedEvmEstimator) BumpFee(ctx context.Context, originalFee EvmFee, f
 	return
 }
 
+func (e *WrappedEvmEstimator) BumpToFee(_ context.Context, target EvmFee, feeLimit uint32, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) (bumpedFee EvmFee, chainSpecificFeeLimit uint32, err error) {
+	if err = validateBumpTarget(target, maxFeePrice, attempts); err != nil {
+		return bumpedFee, 0, err
+	}
+	return target, feeLimit, nil
+}
+
+// validateBumpTarget checks that target is a single type of fee below maxFeePrice, and that it is above the fee of each
+// prior attempt. A legacy gas price is compared with the fee cap of a prior dynamic fee, and a dynamic fee's fee cap
+// and tip cap are both compared with the gas price of a prior legacy fee, as a legacy tx pays its whole gas price as a tip.
+func validateBumpTarget(target EvmFee, maxFeePrice *assets.Wei, attempts []EvmPriorAttempt) error {
+	if target.ValidDynamic() == target.ValidLegacy() {
+		return errors.New("only one dynamic or legacy fee can be defined")
+	}
+	if maxFeePrice != nil {
+		if target.ValidLegacy() && target.Legacy.Cmp(maxFeePrice) > 0 {
+			return errors.Wrapf(commonfee.ErrBumpFeeExceedsLimit, "target gas price of %s would exceed configured max gas price of %s", target.Legacy, maxFeePrice)
+		}
+		if target.ValidDynamic() && target.DynamicFeeCap.Cmp(maxFeePrice) > 0 {
+			return errors.Wrapf(commonfee.ErrBumpFeeExceedsLimit, "target fee cap of %s would exceed configured max gas price of %s", target.DynamicFeeCap, maxFeePrice)
+		}
+	}
+	for _, a := range attempts {
+		above := func(name string, fee, prior *assets.Wei) error {
+			if prior != nil && fee.Cmp(prior) <= 0 {
+				return errors.Wrapf(ErrBumpTargetTooLow, "target %s of %s is not above %s of prior attempt %s", name, fee, prior, a.TxHash)
+			}
+			return nil
+		}
+		priorFeeCap, priorTipCap := a.DynamicFee.FeeCap, a.DynamicFee.TipCap
+		if a.TxType == 0x0 {
+			priorFeeCap, priorTipCap = a.GasPrice, a.GasPrice
+		}
+		if target.ValidLegacy() {
+			if err := above("gas price", target.Legacy, priorFeeCap); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := above("fee cap", target.DynamicFeeCap, priorFeeCap); err != nil {
+			return err
+		}
+		if err := above("tip cap", target.DynamicTipCap, priorTipCap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Config defines an interface for configuration in the gas package
 //
 //go:generate mockery --quiet --name Config --output ./mocks/ --case=underscore
@@ -375,9 +993,10 @@ func bumpGasPrice(cfg bumpConfig, lggr logger.SugaredLogger, currentGasPrice, or
 	return bumpedGasPrice, nil
 }
 
-// BumpDynamicFeeOnly bumps the tip cap and max gas price if necessary
-func BumpDynamicFeeOnly(config bumpConfig, feeCapBufferBlocks uint16, lggr logger.SugaredLogger, currentTipCap, currentBaseFee *assets.Wei, originalFee DynamicFee, originalGasLimit uint32, maxGasPriceWei *assets.Wei) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
-	bumped, err = bumpDynamicFee(config, feeCapBufferBlocks, lggr, currentTipCap, currentBaseFee, originalFee, maxGasPriceWei)
+// BumpDynamicFeeOnly bumps the tip cap and max gas price if necessary. With OptBumpTipOnly in opts, only the tip cap
+// is bumped.
+func BumpDynamicFeeOnly(config bumpConfig, feeCapBufferBlocks uint16, lggr logger.SugaredLogger, currentTipCap, currentBaseFee *assets.Wei, originalFee DynamicFee, originalGasLimit uint32, maxGasPriceWei *assets.Wei, opts ...feetypes.Opt) (bumped DynamicFee, chainSpecificGasLimit uint32, err error) {
+	bumped, err = bumpDynamicFee(config, feeCapBufferBlocks, lggr, currentTipCap, currentBaseFee, originalFee, maxGasPriceWei, slices.Contains(opts, feetypes.OptBumpTipOnly))
 	if err != nil {
 		return bumped, 0, err
 	}
@@ -389,13 +1008,15 @@ func BumpDynamicFeeOnly(config bumpConfig, feeCapBufferBlocks uint16, lggr logge
 // - A configured percentage bump (EVM.GasEstimator.BumpPercent) on top of the baseline tip cap.
 // - A configured fixed amount of Wei (ETH_GAS_PRICE_WEI) on top of the baseline tip cap.
 // The baseline tip cap is the maximum of the previous tip cap attempt and the node's current tip cap.
-// It increases the max fee cap by GasBumpPercent
+// It increases the max fee cap by GasBumpPercent, unless tipOnly is set.
 //
 // NOTE: We would prefer to have set a large FeeCap and leave it fixed, bumping
 // the Tip only. Unfortunately due to a flaw of how EIP-1559 is implemented we
 // have to bump FeeCap by at least 10% each time we bump the tip cap.
 // See: https://github.com/ethereum/go-ethereum/issues/24284
-func bumpDynamicFee(cfg bumpConfig, feeCapBufferBlocks uint16, lggr logger.SugaredLogger, currentTipCap, currentBaseFee *assets.Wei, originalFee DynamicFee, maxGasPriceWei *assets.Wei) (bumpedFee DynamicFee, err error) {
+// tipOnly (EVM.GasEstimator.BumpTipOnly) is therefore only of use on chains whose nodes do not apply geth's
+// replacement rule, as geth-compatible nodes reject every tip-only bump as replacement underpriced.
+func bumpDynamicFee(cfg bumpConfig, feeCapBufferBlocks uint16, lggr logger.SugaredLogger, currentTipCap, currentBaseFee *assets.Wei, originalFee DynamicFee, maxGasPriceWei *assets.Wei, tipOnly bool) (bumpedFee DynamicFee, err error) {
 	maxGasPrice := getMaxGasPrice(maxGasPriceWei, cfg.PriceMax())
 	baselineTipCap := assets.MaxWei(originalFee.TipCap, cfg.TipCapDefault())
 	bumpedTipCap := bumpFeePrice(baselineTipCap, cfg.BumpPercent(), cfg.BumpMin())
@@ -415,6 +1036,13 @@ func bumpDynamicFee(cfg bumpConfig, feeCapBufferBlocks uint16, lggr logger.Sugar
 			"EVM.GasEstimator.BumpPercent or EVM.GasEstimator.BumpMin", bumpedTipCap.String(), originalFee.TipCap.String())
 	}
 
+	if tipOnly {
+		if bumpedTipCap.Cmp(originalFee.FeeCap) > 0 {
+			return bumpedFee, errors.Wrapf(commonfee.ErrBump, "cannot bump tip cap only: bumped tip cap of %s would exceed the fixed fee cap of %s", bumpedTipCap, originalFee.FeeCap)
+		}
+		return DynamicFee{FeeCap: originalFee.FeeCap, TipCap: bumpedTipCap}, nil
+	}
+
 	// Always bump the FeeCap by at least the bump percentage (should be greater than or
 	// equal to than geth's configured bump minimum which is 10%)
 	// See: https://github.com/ethereum/go-ethereum/blob/bff330335b94af3643ac2fb809793f77de3069d4/core/tx_list.go#L298
@@ -437,9 +1065,10 @@ func bumpDynamicFee(cfg bumpConfig, feeCapBufferBlocks uint16, lggr logger.Sugar
 	return DynamicFee{FeeCap: bumpedFeeCap, TipCap: bumpedTipCap}, nil
 }
 
+// bumpFeePrice rounds the percentage bump up, so repeated bumps never fall below the configured percentage
 func bumpFeePrice(originalFeePrice *assets.Wei, feeBumpPercent uint16, feeBumpUnits *assets.Wei) *assets.Wei {
 	bumpedFeePrice := assets.MaxWei(
-		originalFeePrice.AddPercentage(feeBumpPercent),
+		addPercentage(originalFeePrice, feeBumpPercent, RoundUp),
 		originalFeePrice.Add(feeBumpUnits),
 	)
 	return bumpedFeePrice
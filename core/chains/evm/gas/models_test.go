@@ -2,18 +2,25 @@ package gas_test
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
 	rollupMocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/rollups/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 )
 
 func TestWrappedEvmEstimator(t *testing.T) {
@@ -209,3 +216,612 @@ func TestWrappedEvmEstimator(t *testing.T) {
 		require.NotNil(t, report[mockEstimatorName])
 	})
 }
+
+func TestWrappedEvmEstimator_GetFeeForType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	gasLimit := uint32(10)
+	legacyFee := assets.NewWeiI(10)
+	dynamicFee := gas.DynamicFee{
+		FeeCap: assets.NewWeiI(20),
+		TipCap: assets.NewWeiI(1),
+	}
+
+	e := mocks.NewEvmEstimator(t)
+	e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(legacyFee, gasLimit, nil).Twice()
+	e.On("GetDynamicFee", mock.Anything, mock.Anything, mock.Anything).
+		Return(dynamicFee, gasLimit, nil).Once()
+
+	t.Run("legacy fee on non-EIP1559 chain", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		fee, max, err := estimator.GetFeeForType(ctx, 0x0, common.Address{}, nil, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, gasLimit, max)
+		assert.True(t, legacyFee.Equal(fee.Legacy))
+		assert.Nil(t, fee.DynamicFeeCap)
+		assert.Nil(t, fee.DynamicTipCap)
+	})
+
+	t.Run("legacy fee on EIP1559 chain", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, true, nil)
+		fee, _, err := estimator.GetFeeForType(ctx, 0x0, common.Address{}, nil, 0, nil)
+		require.NoError(t, err)
+		assert.True(t, legacyFee.Equal(fee.Legacy))
+		assert.Nil(t, fee.DynamicFeeCap)
+	})
+
+	t.Run("dynamic fee on EIP1559 chain", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, true, nil)
+		fee, max, err := estimator.GetFeeForType(ctx, 0x2, common.Address{}, nil, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, gasLimit, max)
+		assert.True(t, dynamicFee.FeeCap.Equal(fee.DynamicFeeCap))
+		assert.True(t, dynamicFee.TipCap.Equal(fee.DynamicTipCap))
+		assert.Nil(t, fee.Legacy)
+	})
+
+	t.Run("dynamic fee on non-EIP1559 chain errors", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		_, _, err := estimator.GetFeeForType(ctx, 0x2, common.Address{}, nil, 0, nil)
+		require.EqualError(t, err, "cannot estimate fee for type 0x2 transaction: EIP1559 dynamic fees are not enabled")
+	})
+
+	t.Run("unrecognised tx type errors", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, true, nil)
+		_, _, err := estimator.GetFeeForType(ctx, 0x1, common.Address{}, nil, 0, nil)
+		require.EqualError(t, err, "cannot estimate fee for unrecognised transaction type 0x1")
+	})
+}
+
+func TestWrappedEvmEstimator_GetFeeCandidates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	gasLimit := uint32(10)
+
+	e := mocks.NewEvmEstimator(t)
+	e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(assets.NewWeiI(100), gasLimit, nil).Times(3)
+	e.On("GetDynamicFee", mock.Anything, mock.Anything, mock.Anything).
+		Return(gas.DynamicFee{FeeCap: assets.NewWeiI(20), TipCap: assets.NewWeiI(1)}, gasLimit, nil).Twice()
+
+	t.Run("legacy candidates are ordered conservative, normal, aggressive", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		candidates, limit, err := estimator.GetFeeCandidates(ctx, nil, 0, assets.NewWeiI(1000))
+		require.NoError(t, err)
+		assert.Equal(t, gasLimit, limit)
+		assert.Equal(t, []gas.EvmFee{
+			gas.NewLegacyFee(assets.NewWeiI(80)),
+			gas.NewLegacyFee(assets.NewWeiI(100)),
+			gas.NewLegacyFee(assets.NewWeiI(125)),
+		}, candidates)
+	})
+
+	t.Run("candidates are capped at the max fee price", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		candidates, _, err := estimator.GetFeeCandidates(ctx, nil, 0, assets.NewWeiI(110))
+		require.NoError(t, err)
+		assert.Equal(t, []gas.EvmFee{
+			gas.NewLegacyFee(assets.NewWeiI(80)),
+			gas.NewLegacyFee(assets.NewWeiI(100)),
+			gas.NewLegacyFee(assets.NewWeiI(110)),
+		}, candidates)
+	})
+
+	t.Run("dynamic candidates round up", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, true, nil)
+		candidates, _, err := estimator.GetFeeCandidates(ctx, nil, 0, assets.NewWeiI(1000))
+		require.NoError(t, err)
+		assert.Equal(t, []gas.EvmFee{
+			gas.NewDynamicFee(assets.NewWeiI(16), assets.NewWeiI(1)),
+			gas.NewDynamicFee(assets.NewWeiI(20), assets.NewWeiI(1)),
+			gas.NewDynamicFee(assets.NewWeiI(25), assets.NewWeiI(2)),
+		}, candidates)
+	})
+
+	t.Run("legacy candidates are raised to the min gas price", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		estimator.SetMinFees(assets.NewWeiI(90), nil)
+		candidates, _, err := estimator.GetFeeCandidates(ctx, nil, 0, assets.NewWeiI(1000))
+		require.NoError(t, err)
+		assert.Equal(t, []gas.EvmFee{
+			gas.NewLegacyFee(assets.NewWeiI(90)),
+			gas.NewLegacyFee(assets.NewWeiI(100)),
+			gas.NewLegacyFee(assets.NewWeiI(125)),
+		}, candidates)
+	})
+
+	t.Run("dynamic candidates are raised to the min tip cap and keep the fee cap above it", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, true, nil)
+		estimator.SetMinFees(nil, assets.NewWeiI(18))
+		candidates, _, err := estimator.GetFeeCandidates(ctx, nil, 0, assets.NewWeiI(1000))
+		require.NoError(t, err)
+		assert.Equal(t, []gas.EvmFee{
+			gas.NewDynamicFee(assets.NewWeiI(18), assets.NewWeiI(18)),
+			gas.NewDynamicFee(assets.NewWeiI(20), assets.NewWeiI(18)),
+			gas.NewDynamicFee(assets.NewWeiI(25), assets.NewWeiI(18)),
+		}, candidates)
+	})
+}
+
+func TestWrappedEvmEstimator_BumpToFee(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	estimator := gas.NewWrappedEvmEstimator(mocks.NewEvmEstimator(t), true, nil)
+	maxPrice := assets.GWei(100)
+	legacyPrior := gas.EvmPriorAttempt{TxType: 0x0, GasPrice: assets.GWei(20)}
+	dynamicPrior := gas.EvmPriorAttempt{TxType: 0x2, DynamicFee: gas.DynamicFee{FeeCap: assets.GWei(30), TipCap: assets.GWei(3)}}
+
+	t.Run("returns a valid target with the fee limit unchanged", func(t *testing.T) {
+		target := gas.NewLegacyFee(assets.GWei(50))
+		fee, limit, err := estimator.BumpToFee(ctx, target, 21000, maxPrice, []gas.EvmPriorAttempt{legacyPrior})
+		require.NoError(t, err)
+		assert.Equal(t, target, fee)
+		assert.Equal(t, uint32(21000), limit)
+
+		target = gas.NewDynamicFee(assets.GWei(40), assets.GWei(4))
+		fee, _, err = estimator.BumpToFee(ctx, target, 21000, maxPrice, []gas.EvmPriorAttempt{dynamicPrior})
+		require.NoError(t, err)
+		assert.Equal(t, target, fee)
+	})
+
+	t.Run("rejects a target that is not above a prior attempt", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			target gas.EvmFee
+			prior  gas.EvmPriorAttempt
+		}{
+			{"legacy equal to prior", gas.NewLegacyFee(assets.GWei(20)), legacyPrior},
+			{"legacy below dynamic fee cap", gas.NewLegacyFee(assets.GWei(25)), dynamicPrior},
+			{"dynamic fee cap below prior", gas.NewDynamicFee(assets.GWei(29), assets.GWei(4)), dynamicPrior},
+			{"dynamic tip cap below prior", gas.NewDynamicFee(assets.GWei(40), assets.GWei(2)), dynamicPrior},
+			{"dynamic tip cap below legacy price", gas.NewDynamicFee(assets.GWei(40), assets.GWei(10)), legacyPrior},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := estimator.BumpToFee(ctx, tc.target, 21000, maxPrice, []gas.EvmPriorAttempt{tc.prior})
+				require.ErrorIs(t, err, gas.ErrBumpTargetTooLow)
+			})
+		}
+	})
+
+	t.Run("rejects a target above the max fee price", func(t *testing.T) {
+		_, _, err := estimator.BumpToFee(ctx, gas.NewLegacyFee(assets.GWei(101)), 21000, maxPrice, nil)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+		_, _, err = estimator.BumpToFee(ctx, gas.NewDynamicFee(assets.GWei(101), assets.GWei(4)), 21000, maxPrice, nil)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+	})
+
+	t.Run("rejects a mixed target", func(t *testing.T) {
+		_, _, err := estimator.BumpToFee(ctx, gas.EvmFee{Legacy: assets.GWei(50), DynamicFeeCap: assets.GWei(50), DynamicTipCap: assets.GWei(5)}, 21000, maxPrice, nil)
+		require.EqualError(t, err, "only one dynamic or legacy fee can be defined")
+	})
+}
+
+func TestWrappedEvmEstimator_Warm(t *testing.T) {
+	t.Parallel()
+	e := mocks.NewEvmEstimator(t)
+	e.On("Name").Return("Estimator").Maybe()
+	e.On("OnNewLongestChain", mock.Anything, mock.Anything)
+	estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+	head := &evmtypes.Head{Number: 1}
+
+	t.Run("not warm before the first head", func(t *testing.T) {
+		assert.False(t, estimator.IsWarm())
+	})
+
+	t.Run("times out without enough heads", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 10*time.Millisecond)
+		defer cancel()
+		estimator.OnNewLongestChain(ctx, head)
+		err := estimator.Warm(ctx, 3)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "ingested 1 of 3 heads needed to warm up")
+		assert.False(t, estimator.IsWarm())
+	})
+
+	t.Run("flips to warm after enough heads", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		warm := make(chan error)
+		go func() { warm <- estimator.Warm(ctx, 3) }()
+
+		estimator.OnNewLongestChain(ctx, head)
+		assert.False(t, estimator.IsWarm())
+		estimator.OnNewLongestChain(ctx, head)
+		require.NoError(t, <-warm)
+		assert.True(t, estimator.IsWarm())
+
+		require.NoError(t, estimator.Warm(ctx, 2), "returns straight away once warm")
+	})
+}
+
+func TestWrappedEvmEstimator_StaleFeeGracePeriod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	gasLimit := uint32(10)
+	legacyFee := assets.NewWeiI(10)
+	rpcErr := errors.New("rpc unavailable")
+
+	newEstimator := func(t *testing.T, gracePeriod time.Duration) *gas.WrappedEvmEstimator {
+		e := mocks.NewEvmEstimator(t)
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(legacyFee, gasLimit, nil).Once()
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, uint32(0), rpcErr)
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil).(*gas.WrappedEvmEstimator)
+		estimator.SetStaleFeeGracePeriod(gracePeriod)
+		return estimator
+	}
+
+	t.Run("short outage is served from cache", func(t *testing.T) {
+		estimator := newEstimator(t, time.Hour)
+
+		fee, max, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+		assert.False(t, fee.Stale)
+
+		fee, max, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+		assert.True(t, fee.Stale)
+		assert.True(t, legacyFee.Equal(fee.Legacy))
+		assert.Equal(t, gasLimit, max)
+	})
+
+	t.Run("cached fee is not served above max fee price", func(t *testing.T) {
+		estimator := newEstimator(t, time.Hour)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+
+		_, _, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(5))
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("long outage errors", func(t *testing.T) {
+		estimator := newEstimator(t, time.Millisecond)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		_, _, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		estimator := newEstimator(t, 0)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+
+		_, _, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("cached fee is not served for different calldata", func(t *testing.T) {
+		estimator := newEstimator(t, time.Hour)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+
+		_, _, err = estimator.GetFee(ctx, []byte{1, 2, 3}, gasLimit, assets.NewWeiI(100))
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("fees estimated with a value opt are not cached", func(t *testing.T) {
+		withBaseFee := feetypes.WithBaseFee(assets.NewWeiI(1))
+		e := mocks.NewEvmEstimator(t)
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, withBaseFee).
+			Return(legacyFee, gasLimit, nil).Once()
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, uint32(0), rpcErr).Once()
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil).(*gas.WrappedEvmEstimator)
+		estimator.SetStaleFeeGracePeriod(time.Hour)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100), withBaseFee)
+		require.NoError(t, err)
+
+		_, _, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.ErrorIs(t, err, rpcErr)
+	})
+
+	t.Run("cached fee is not served for a value opt", func(t *testing.T) {
+		withBaseFee := feetypes.WithBaseFee(assets.NewWeiI(1))
+		e := mocks.NewEvmEstimator(t)
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(legacyFee, gasLimit, nil).Once()
+		e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, withBaseFee).
+			Return(nil, uint32(0), rpcErr).Once()
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil).(*gas.WrappedEvmEstimator)
+		estimator.SetStaleFeeGracePeriod(time.Hour)
+
+		_, _, err := estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100))
+		require.NoError(t, err)
+
+		_, _, err = estimator.GetFee(ctx, nil, gasLimit, assets.NewWeiI(100), withBaseFee)
+		require.ErrorIs(t, err, rpcErr)
+	})
+}
+
+func TestEvmFee_String(t *testing.T) {
+	t.Parallel()
+
+	t.Run("legacy only", func(t *testing.T) {
+		fee := gas.EvmFee{Legacy: assets.GWei(10)}
+		assert.Equal(t, "EvmFee(legacy){Legacy: 10 gwei}", fee.String())
+		assert.Equal(t, []interface{}{"feeType", "legacy", "gasPrice", assets.GWei(10)}, fee.LoggerFields())
+	})
+	t.Run("dynamic only", func(t *testing.T) {
+		fee := gas.EvmFee{DynamicFeeCap: assets.GWei(20), DynamicTipCap: assets.GWei(1)}
+		assert.Equal(t, "EvmFee(dynamic){DynamicFeeCap: 20 gwei, DynamicTipCap: 1 gwei}", fee.String())
+		assert.Equal(t, []interface{}{"feeType", "dynamic", "gasFeeCap", assets.GWei(20), "gasTipCap", assets.GWei(1)}, fee.LoggerFields())
+	})
+	t.Run("empty", func(t *testing.T) {
+		fee := gas.EvmFee{}
+		assert.Equal(t, "EvmFee(empty){}", fee.String())
+		assert.Equal(t, []interface{}{"feeType", "empty"}, fee.LoggerFields())
+	})
+	t.Run("stale", func(t *testing.T) {
+		fee := gas.EvmFee{Legacy: assets.GWei(10), Stale: true}
+		assert.Equal(t, "EvmFee(legacy){Legacy: 10 gwei, Stale: true}", fee.String())
+		assert.Equal(t, []interface{}{"feeType", "legacy", "gasPrice", assets.GWei(10), "feeStale", true}, fee.LoggerFields())
+	})
+}
+
+func TestEvmFee_Constructors(t *testing.T) {
+	t.Parallel()
+
+	legacy := gas.NewLegacyFee(assets.GWei(10))
+	assert.Equal(t, gas.EvmFee{Legacy: assets.GWei(10)}, legacy)
+	assert.True(t, legacy.ValidLegacy())
+	assert.False(t, legacy.ValidDynamic())
+
+	dynamic := gas.NewDynamicFee(assets.GWei(20), assets.GWei(1))
+	assert.Equal(t, gas.EvmFee{DynamicFeeCap: assets.GWei(20), DynamicTipCap: assets.GWei(1)}, dynamic)
+	assert.True(t, dynamic.ValidDynamic())
+	assert.False(t, dynamic.ValidLegacy())
+
+	for _, tt := range []struct {
+		name    string
+		fee     gas.EvmFee
+		legacy  bool
+		dynamic bool
+	}{
+		{"empty", gas.EvmFee{}, false, false},
+		{"nil legacy price", gas.NewLegacyFee(nil), false, false},
+		{"fee cap only", gas.EvmFee{DynamicFeeCap: assets.GWei(20)}, false, false},
+		{"tip cap only", gas.EvmFee{DynamicTipCap: assets.GWei(1)}, false, false},
+		{"mixed", gas.EvmFee{Legacy: assets.GWei(10), DynamicFeeCap: assets.GWei(20), DynamicTipCap: assets.GWei(1)}, true, true},
+	} {
+		assert.Equal(t, tt.legacy, tt.fee.ValidLegacy(), tt.name)
+		assert.Equal(t, tt.dynamic, tt.fee.ValidDynamic(), tt.name)
+	}
+}
+
+func TestEvmFee_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	for _, fee := range []gas.EvmFee{
+		{},
+		gas.NewLegacyFee(assets.GWei(10)),
+		gas.NewLegacyFee(assets.NewWeiI(0)),
+		gas.NewDynamicFee(assets.GWei(20), assets.GWei(1)),
+		{Legacy: assets.GWei(10), DynamicTipCap: assets.GWei(1), Stale: true},
+	} {
+		b, err := fee.MarshalBinary()
+		require.NoError(t, err)
+		var decoded gas.EvmFee
+		require.NoError(t, decoded.UnmarshalBinary(b))
+		assert.Equal(t, fee, decoded, fee.String())
+	}
+
+	_, err := gas.NewLegacyFee(assets.NewWeiI(-1)).MarshalBinary()
+	require.ErrorContains(t, err, "cannot marshal negative fee component")
+
+	var decoded gas.EvmFee
+	require.ErrorContains(t, decoded.UnmarshalBinary([]byte{0x10}), "unknown flags")
+	require.ErrorContains(t, decoded.UnmarshalBinary([]byte{0x01, 0, 0, 0, 2, 1}), "data too short")
+}
+
+func TestEvmFee_JSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		fee  gas.EvmFee
+		json string
+	}{
+		{"legacy", gas.NewLegacyFee(assets.GWei(10)), `{"type":"legacy","gasPrice":"10 gwei"}`},
+		{"dynamic", gas.NewDynamicFee(assets.GWei(20), assets.NewWeiI(5)), `{"type":"dynamic","gasFeeCap":"20 gwei","gasTipCap":"5 wei"}`},
+		{"empty", gas.EvmFee{}, `{"type":"empty"}`},
+		{"stale", gas.EvmFee{Legacy: assets.GWei(10), Stale: true}, `{"type":"legacy","gasPrice":"10 gwei","stale":true}`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.fee)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.json, string(b))
+
+			var decoded gas.EvmFee
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &decoded))
+			assert.Equal(t, tc.fee, decoded)
+		})
+	}
+
+	t.Run("rejects a type that does not match the components", func(t *testing.T) {
+		var decoded gas.EvmFee
+		err := json.Unmarshal([]byte(`{"type":"legacy","gasFeeCap":"20 gwei","gasTipCap":"5 wei"}`), &decoded)
+		require.ErrorContains(t, err, `type "legacy" does not match its components, which imply "dynamic"`)
+	})
+}
+
+func TestEvmFee_Scale(t *testing.T) {
+	t.Parallel()
+
+	t.Run("legacy", func(t *testing.T) {
+		fee := gas.EvmFee{Legacy: assets.GWei(10)}
+		assert.Equal(t, gas.EvmFee{Legacy: assets.GWei(15)}, fee.Scale(3, 2, gas.RoundDown))
+		assert.Equal(t, assets.GWei(10), fee.Legacy, "original must be unchanged")
+	})
+	t.Run("dynamic rounds down and keeps nil components nil", func(t *testing.T) {
+		fee := gas.EvmFee{DynamicFeeCap: assets.NewWeiI(10), DynamicTipCap: assets.NewWeiI(5)}
+		scaled := fee.Scale(1, 3, gas.RoundDown)
+		assert.Equal(t, assets.NewWeiI(3), scaled.DynamicFeeCap)
+		assert.Equal(t, assets.NewWeiI(1), scaled.DynamicTipCap)
+		assert.Nil(t, scaled.Legacy)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, gas.EvmFee{}, gas.EvmFee{}.Scale(2, 1, gas.RoundUp))
+	})
+	t.Run("rounding modes", func(t *testing.T) {
+		for _, tt := range []struct {
+			name              string
+			wei               int64
+			down, up, nearest int64
+		}{
+			{"exact", 20, 22, 22, 22},
+			{"below half", 21, 23, 24, 23},
+			{"half", 25, 27, 28, 28},
+			{"above half", 29, 31, 32, 32},
+		} {
+			fee := gas.EvmFee{Legacy: assets.NewWeiI(tt.wei)}
+			assert.Equal(t, assets.NewWeiI(tt.down), fee.Scale(110, 100, gas.RoundDown).Legacy, tt.name)
+			assert.Equal(t, assets.NewWeiI(tt.up), fee.Scale(110, 100, gas.RoundUp).Legacy, tt.name)
+			assert.Equal(t, assets.NewWeiI(tt.nearest), fee.Scale(110, 100, gas.RoundNearest).Legacy, tt.name)
+		}
+	})
+}
+
+func TestEvmFee_Cmp(t *testing.T) {
+	t.Parallel()
+
+	legacy := func(gwei int64) gas.EvmFee { return gas.EvmFee{Legacy: assets.GWei(gwei)} }
+	dynamic := func(feeCap, tipCap int64) gas.EvmFee {
+		return gas.EvmFee{DynamicFeeCap: assets.GWei(feeCap), DynamicTipCap: assets.GWei(tipCap)}
+	}
+	for _, tt := range []struct {
+		name     string
+		a, b     gas.EvmFee
+		expected int
+	}{
+		{"legacy equal", legacy(10), legacy(10), 0},
+		{"legacy less", legacy(9), legacy(10), -1},
+		{"dynamic higher fee cap", dynamic(20, 1), dynamic(10, 5), 1},
+		{"dynamic tie broken on tip", dynamic(20, 1), dynamic(20, 2), -1},
+		{"legacy equals dynamic with same caps", legacy(10), dynamic(10, 10), 0},
+		{"legacy beats dynamic with lower tip", legacy(10), dynamic(10, 2), 1},
+		{"legacy below dynamic fee cap", legacy(10), dynamic(20, 2), -1},
+		{"dynamic takes precedence in mixed fee", gas.EvmFee{Legacy: assets.GWei(50), DynamicFeeCap: assets.GWei(20), DynamicTipCap: assets.GWei(2)}, legacy(30), -1},
+		{"nil components compare as zero", gas.EvmFee{DynamicFeeCap: assets.GWei(10)}, dynamic(10, 0), 0},
+		{"empty", gas.EvmFee{}, legacy(0), 0},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.a.Cmp(tt.b))
+			assert.Equal(t, -tt.expected, tt.b.Cmp(tt.a))
+			assert.Equal(t, tt.expected > 0, tt.a.GreaterThan(tt.b))
+			assert.Equal(t, tt.expected < 0, tt.b.GreaterThan(tt.a))
+		})
+	}
+}
+
+func TestWrappedEvmEstimator_GetFeeHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("returns ErrNotSupported for estimators without history", func(t *testing.T) {
+		e := mocks.NewEvmEstimator(t)
+		e.On("Name").Return("FixedPriceEstimator")
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		_, err := estimator.GetFeeHistory(ctx, 10, []float64{50})
+		require.ErrorIs(t, err, gas.ErrNotSupported)
+	})
+
+	t.Run("mock", func(t *testing.T) {
+		history := &gas.FeeHistory{
+			OldestBlock: 5,
+			BaseFees:    []*assets.Wei{assets.NewWeiI(100)},
+			Rewards:     [][]*assets.Wei{{assets.NewWeiI(1), assets.NewWeiI(2)}},
+		}
+		estimator := mocks.NewEvmFeeEstimator(t)
+		estimator.On("GetFeeHistory", mock.Anything, uint64(1), []float64{10, 90}).Return(history, nil).Once()
+		estimator.On("GetFeeHistory", mock.Anything, uint64(1), []float64{50}).Return(nil, gas.ErrNotSupported).Once()
+
+		res, err := estimator.GetFeeHistory(ctx, 1, []float64{10, 90})
+		require.NoError(t, err)
+		assert.Equal(t, history, res)
+		_, err = estimator.GetFeeHistory(ctx, 1, []float64{50})
+		require.ErrorIs(t, err, gas.ErrNotSupported)
+	})
+}
+
+func TestWrappedEvmEstimator_GetMaxCostBreakdown(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	gasLimit := uint32(10)
+	legacyFee := assets.NewWeiI(10)
+	val := assets.NewEthValue(1)
+	// 2 non-zero bytes and 1 zero byte = 2*16 + 4 gas
+	calldata := []byte{0x01, 0x00, 0x02}
+	expectedL2 := new(big.Int).Add(val.ToInt(), new(big.Int).Mul(legacyFee.ToInt(), big.NewInt(int64(gasLimit))))
+
+	e := mocks.NewEvmEstimator(t)
+	e.On("GetLegacyGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(legacyFee, gasLimit, nil)
+
+	t.Run("without an L1 oracle", func(t *testing.T) {
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+		l2, l1Min, err := estimator.GetMaxCostBreakdown(ctx, val, calldata, gasLimit, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedL2, l2)
+		assert.Equal(t, big.NewInt(0), l1Min)
+	})
+	t.Run("with an L1 oracle", func(t *testing.T) {
+		oracle := rollupMocks.NewL1Oracle(t)
+		oracle.On("GasPrice", mock.Anything).Return(assets.NewWeiI(100), nil).Once()
+		estimator := gas.NewWrappedEvmEstimator(e, false, oracle)
+		l2, l1Min, err := estimator.GetMaxCostBreakdown(ctx, val, calldata, gasLimit, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedL2, l2)
+		// only the calldata gas is priced, so this is a lower bound on the actual L1 fee
+		assert.Equal(t, big.NewInt(100*36), l1Min)
+
+		total, err := estimator.GetMaxCost(ctx, val, calldata, gasLimit, nil)
+		require.NoError(t, err)
+		assert.Equal(t, total, l2)
+	})
+	t.Run("L1 oracle error", func(t *testing.T) {
+		oracle := rollupMocks.NewL1Oracle(t)
+		oracle.On("GasPrice", mock.Anything).Return(nil, errors.New("boom")).Once()
+		estimator := gas.NewWrappedEvmEstimator(e, false, oracle)
+		_, _, err := estimator.GetMaxCostBreakdown(ctx, val, calldata, gasLimit, nil)
+		require.EqualError(t, err, "failed to get L1 gas price: boom")
+	})
+}
+
+func TestWrappedEvmEstimator_EstimateGasLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("delegates to the underlying estimator", func(t *testing.T) {
+		e := mocks.NewEvmEstimator(t)
+		e.On("EstimateGasLimit", mock.Anything, []byte{1}, uint32(21000)).Return(uint32(25000), nil).Once()
+		estimator := gas.NewWrappedEvmEstimator(e, false, nil)
+
+		limit, err := estimator.EstimateGasLimit(ctx, []byte{1}, 21000)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(25000), limit)
+	})
+
+	t.Run("mock", func(t *testing.T) {
+		estimator := mocks.NewEvmFeeEstimator(t)
+		estimator.On("EstimateGasLimit", mock.Anything, mock.Anything, uint32(21000)).Return(uint32(23100), nil).Once()
+		estimator.On("EstimateGasLimit", mock.Anything, mock.Anything, uint32(0)).Return(uint32(0), errors.New("limit must be positive")).Once()
+
+		limit, err := estimator.EstimateGasLimit(ctx, nil, 21000)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(23100), limit)
+		_, err = estimator.EstimateGasLimit(ctx, nil, 0)
+		require.EqualError(t, err, "limit must be positive")
+	})
+}
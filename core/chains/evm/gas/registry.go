@@ -0,0 +1,83 @@
+package gas
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	evmconfig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/rollups"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// EstimatorFactory constructs an EvmFeeEstimator for a chain
+type EstimatorFactory func(lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) EvmFeeEstimator
+
+var (
+	estimatorsMu sync.RWMutex
+	estimators   = map[string]EstimatorFactory{}
+)
+
+func init() {
+	RegisterEstimator("Arbitrum", func(lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) EvmFeeEstimator {
+		return NewWrappedEvmEstimator(NewArbitrumEstimator(lggr, geCfg, ethClient, ethClient), geCfg.EIP1559DynamicFees(), newL1Oracle(lggr, ethClient, cfg))
+	})
+	RegisterEstimator("BlockHistory", func(lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) EvmFeeEstimator {
+		return NewWrappedEvmEstimator(NewBlockHistoryEstimator(lggr, ethClient, cfg, geCfg, geCfg.BlockHistory(), *ethClient.ConfiguredChainID()), geCfg.EIP1559DynamicFees(), newL1Oracle(lggr, ethClient, cfg))
+	})
+	RegisterEstimator("FixedPrice", func(lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) EvmFeeEstimator {
+		return NewWrappedEvmEstimator(NewFixedPriceEstimator(geCfg, geCfg.BlockHistory(), lggr), geCfg.EIP1559DynamicFees(), newL1Oracle(lggr, ethClient, cfg))
+	})
+	suggested := func(lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) EvmFeeEstimator {
+		return NewWrappedEvmEstimator(NewSuggestedPriceEstimator(lggr, ethClient), geCfg.EIP1559DynamicFees(), newL1Oracle(lggr, ethClient, cfg))
+	}
+	RegisterEstimator("L2Suggested", suggested)
+	RegisterEstimator("SuggestedPrice", suggested)
+}
+
+// newL1Oracle creates an l1Oracle only if it is supported for the chain
+func newL1Oracle(lggr logger.Logger, ethClient evmclient.Client, cfg Config) rollups.L1Oracle {
+	if rollups.IsRollupWithL1Support(cfg.ChainType()) {
+		return rollups.NewL1GasPriceOracle(lggr, ethClient, cfg.ChainType())
+	}
+	return nil
+}
+
+// RegisterEstimator makes an estimator available by name to NewEstimatorByName.
+// Panics if fn is nil or the name is already registered.
+func RegisterEstimator(name string, fn EstimatorFactory) {
+	estimatorsMu.Lock()
+	defer estimatorsMu.Unlock()
+	if fn == nil {
+		panic("gas: RegisterEstimator factory is nil for " + name)
+	}
+	if _, dup := estimators[name]; dup {
+		panic("gas: RegisterEstimator called twice for " + name)
+	}
+	estimators[name] = fn
+}
+
+// Estimators returns the sorted names of all registered estimators
+func Estimators() []string {
+	estimatorsMu.RLock()
+	defer estimatorsMu.RUnlock()
+	names := make([]string, 0, len(estimators))
+	for name := range estimators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewEstimatorByName builds the estimator registered under name
+func NewEstimatorByName(name string, lggr logger.Logger, ethClient evmclient.Client, cfg Config, geCfg evmconfig.GasEstimator) (EvmFeeEstimator, error) {
+	estimatorsMu.RLock()
+	fn, ok := estimators[name]
+	estimatorsMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unrecognised gas estimator %q, must be one of: %v", name, Estimators())
+	}
+	return fn(lggr, ethClient, cfg, geCfg), nil
+}
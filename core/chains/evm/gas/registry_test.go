@@ -0,0 +1,47 @@
+package gas_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	evmconfig "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestRegisterEstimator(t *testing.T) {
+	t.Parallel()
+
+	fake := mocks.NewEvmFeeEstimator(t)
+	gas.RegisterEstimator("TestFake", func(logger.Logger, evmclient.Client, gas.Config, evmconfig.GasEstimator) gas.EvmFeeEstimator {
+		return fake
+	})
+
+	t.Run("builds a registered estimator by name", func(t *testing.T) {
+		e, err := gas.NewEstimatorByName("TestFake", logger.TestLogger(t), nil, nil, nil)
+		require.NoError(t, err)
+		assert.Same(t, fake, e)
+		assert.Contains(t, gas.Estimators(), "TestFake")
+	})
+
+	t.Run("errors on an unknown name", func(t *testing.T) {
+		_, err := gas.NewEstimatorByName("DoesNotExist", logger.TestLogger(t), nil, nil, nil)
+		require.ErrorContains(t, err, `unrecognised gas estimator "DoesNotExist"`)
+	})
+
+	t.Run("includes the built-in estimators", func(t *testing.T) {
+		assert.Subset(t, gas.Estimators(), []string{"Arbitrum", "BlockHistory", "FixedPrice", "L2Suggested", "SuggestedPrice"})
+	})
+
+	t.Run("panics on a duplicate name", func(t *testing.T) {
+		assert.Panics(t, func() {
+			gas.RegisterEstimator("TestFake", func(logger.Logger, evmclient.Client, gas.Config, evmconfig.GasEstimator) gas.EvmFeeEstimator {
+				return fake
+			})
+		})
+	})
+}
@@ -2,26 +2,168 @@ package txmgr
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/pkg/errors"
+	"github.com/ethereum/go-ethereum/params"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/smartcontractkit/chainlink/v2/common/config"
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
 	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
 	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
 	commontypes "github.com/smartcontractkit/chainlink/v2/common/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/rollups"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
+var (
+	promAttemptBuildCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tx_manager_attempt_build_count",
+		Help: "Number of transaction attempts built, labeled by attempt builder method and tx type",
+	}, []string{"chainID", "method", "txType"})
+	promAttemptBuildErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tx_manager_attempt_build_errors",
+		Help: "Number of failures to build a transaction attempt, labeled by attempt builder method and whether the failure is retryable",
+	}, []string{"chainID", "method", "retryable"})
+	promTimeToBuildAttempt = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "tx_manager_time_to_build_attempt",
+		Help: "The amount of time taken to build a transaction attempt, including fee estimation and signing.",
+		Buckets: []float64{
+			float64(10 * time.Millisecond),
+			float64(50 * time.Millisecond),
+			float64(100 * time.Millisecond),
+			float64(500 * time.Millisecond),
+			float64(time.Second),
+			float64(5 * time.Second),
+			float64(10 * time.Second),
+		},
+	}, []string{"chainID", "method"})
+)
+
+// AttemptBuilderMetrics records the outcome of each call to a public attempt builder method
+type AttemptBuilderMetrics interface {
+	RecordAttemptBuild(method string, txType int, elapsed time.Duration, err error, retryable bool)
+}
+
+type promAttemptBuilderMetrics struct {
+	chainID string
+}
+
+func (m *promAttemptBuilderMetrics) RecordAttemptBuild(method string, txType int, elapsed time.Duration, err error, retryable bool) {
+	promAttemptBuildCount.WithLabelValues(m.chainID, method, strconv.Itoa(txType)).Inc()
+	promTimeToBuildAttempt.WithLabelValues(m.chainID, method).Observe(float64(elapsed))
+	if err != nil {
+		promAttemptBuildErrors.WithLabelValues(m.chainID, method, strconv.FormatBool(retryable)).Inc()
+	}
+}
+
 type TxAttemptSigner[ADDR commontypes.Hashable] interface {
 	SignTx(fromAddress ADDR, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
 }
 
+// ContextTxAttemptSigner is implemented by signers that make remote calls, e.g. to an HSM, so signing can be cancelled.
+// The builder prefers SignTxContext over SignTx when it is available.
+type ContextTxAttemptSigner[ADDR commontypes.Hashable] interface {
+	SignTxContext(ctx context.Context, fromAddress ADDR, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// DeterministicTxAttemptSigner is implemented by signers whose signatures depend only on the key and the tx, so that
+// identical inputs always build byte-identical attempts. Required when SetBuildDeterministic is enabled.
+type DeterministicTxAttemptSigner interface {
+	TxAttemptSigner[common.Address]
+	Deterministic() bool
+}
+
+// signTxWith signs tx with SignTxContext if the signer supports it, otherwise with SignTx
+func signTxWith(ctx context.Context, signer TxAttemptSigner[common.Address], address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cs, ok := signer.(ContextTxAttemptSigner[common.Address]); ok {
+		return cs.SignTxContext(ctx, address, tx, chainID)
+	}
+	return signer.SignTx(address, tx, chainID)
+}
+
+// SignatureScheme signs a transaction using the given signer and encodes it for broadcast.
+// Chains with non-standard signing or recovery-id schemes can provide their own implementation.
+type SignatureScheme interface {
+	Sign(ctx context.Context, signer TxAttemptSigner[common.Address], address common.Address, tx *types.Transaction, chainID *big.Int) (hash common.Hash, signedRawTx []byte, err error)
+}
+
+// EvmSignatureScheme is the standard EVM signing scheme: the tx is signed by the keystore and RLP encoded
+type EvmSignatureScheme struct{}
+
+func (EvmSignatureScheme) Sign(ctx context.Context, signer TxAttemptSigner[common.Address], address common.Address, tx *types.Transaction, chainID *big.Int) (common.Hash, []byte, error) {
+	signedTx, err := signTxWith(ctx, signer, address, tx, chainID)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	rlp := new(bytes.Buffer)
+	if err := signedTx.EncodeRLP(rlp); err != nil {
+		return common.Hash{}, nil, err
+	}
+	return signedTx.Hash(), rlp.Bytes(), nil
+}
+
+// SignatureSchemeForChainType returns the signature scheme used to sign transactions for the given chain type.
+// All currently supported chain types use standard EVM signing.
+func SignatureSchemeForChainType(chainType config.ChainType) SignatureScheme {
+	return EvmSignatureScheme{}
+}
+
+// enforcesGethReplacementRule reports whether the nodes of the chain type run geth's tx pool, which only accepts a
+// replacement that raises both the fee cap and the tip cap by at least 10%, see go-ethereum#24284
+func enforcesGethReplacementRule(chainType config.ChainType) bool {
+	switch chainType {
+	case "", config.ChainOptimismBedrock, config.ChainKroma, config.ChainCelo, config.ChainWeMix:
+		return true
+	}
+	return false
+}
+
+// NonceProvider supplies the next nonce for an address, for txs that reach the attempt builder without a sequence
+type NonceProvider interface {
+	NextNonce(address common.Address) (evmtypes.Nonce, error)
+}
+
+// BaseFeeSource supplies the base fee of the latest head, used to derive fee caps for tip-only dynamic fees
+type BaseFeeSource interface {
+	LatestBaseFee() (*assets.Wei, error)
+}
+
+// ErrDuplicateIdempotencyKey is returned by BuildWithIdempotencyKey when an attempt has already been built for the key
+var ErrDuplicateIdempotencyKey = pkgerrors.New("attempt already built for idempotency key")
+
+// ErrSignerUnavailable is returned when signing for a key is suspended by the circuit breaker, see SetSignerCircuitBreaker
+var ErrSignerUnavailable = pkgerrors.New("signer unavailable")
+
+// ErrSenderMismatch is returned when a signed attempt does not recover to the tx's from address, see SetVerifySender
+var ErrSenderMismatch = pkgerrors.New("signed tx sender does not match from address")
+
+// ErrMissingSequence is returned when building an attempt for a tx without a sequence and no NonceProvider is configured
+var ErrMissingSequence = pkgerrors.New("tx has no sequence and no nonce provider is configured")
+
 var _ TxAttemptBuilder = (*evmTxAttemptBuilder)(nil)
 
 type evmTxAttemptBuilder struct {
@@ -29,17 +171,442 @@ type evmTxAttemptBuilder struct {
 	feeConfig evmTxAttemptBuilderFeeConfig
 	keystore  TxAttemptSigner[common.Address]
 	gas.EvmFeeEstimator
+	signatureScheme SignatureScheme
+	nonceProvider   NonceProvider
+
+	// l1OracleOverride is used instead of the estimator's L1 oracle for txs flagged with TxMeta.L1OracleOverride
+	l1OracleOverride rollups.L1Oracle
+	// feeEstimationTimeout bounds estimator calls, zero means no timeout
+	feeEstimationTimeout time.Duration
+	metrics              AttemptBuilderMetrics
+	// strictEncoding verifies that each signed tx decodes back to a tx with the same hash
+	strictEncoding bool
+	// buildDeterministic refuses to sign unless the keystore is a DeterministicTxAttemptSigner
+	buildDeterministic bool
+	// verifySender checks that each signed attempt recovers to the tx's from address
+	verifySender bool
+	// assumptionViolationFatal logs invariant failures as assumption violations rather than plain errors
+	assumptionViolationFatal bool
+
+	idempotencyKeysMu sync.Mutex
+	// idempotencyKeys are the keys BuildWithIdempotencyKey has built attempts for, with when they were built
+	idempotencyKeys map[string]time.Time
+	// idempotencyKeyRetention is how long a key is rejected as a duplicate after its attempt was built
+	idempotencyKeyRetention time.Duration
+	// idempotencyKeysPrunedAt is when expired keys were last removed from idempotencyKeys
+	idempotencyKeysPrunedAt time.Time
+	// now is the clock used to expire idempotency keys
+	now func() time.Time
+
+	// feeCacheTTL is how long estimated fees are reused for identical txs, zero disables the cache
+	feeCacheTTL time.Duration
+	feeCacheMu  sync.Mutex
+	feeCache    map[attemptFeeCacheKey]attemptFeeCacheEntry
+
+	// baseFeeSource is used to derive the fee cap of dynamic fees that only have a tip cap set
+	baseFeeSource BaseFeeSource
+
+	// lastHead is the previous head passed to OnNewLongestChain, used to detect reorgs
+	lastHeadMu sync.Mutex
+	lastHead   *evmtypes.Head
+
+	// bumpBroadcast is called with each bumped attempt, nil disables underpriced retries
+	bumpBroadcast BumpBroadcastFunc
+	// bumpBroadcastRetries is how many times a bump rejected as replacement underpriced is retried with a larger bump
+	bumpBroadcastRetries int
+
+	// feeDecisionObserver is notified of the fee of each attempt built from an estimate, may be nil
+	feeDecisionObserver FeeDecisionObserver
+
+	// gasUsedHistory sizes the fee limit of new attempts from recent gas used, nil uses the tx's fee limit
+	gasUsedHistory       GasUsedHistory
+	gasUsedPercentile    float64
+	gasUsedMarginPercent uint16
+
+	// signerBreakerThreshold is how many consecutive signing failures open the breaker for a key, zero disables it
+	signerBreakerThreshold int
+	signerBreakerCooldown  time.Duration
+	signerBreakersMu       sync.Mutex
+	signerBreakers         map[common.Address]*signerBreaker
+}
+
+// signerBreaker tracks consecutive signing failures for a key
+type signerBreaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+type attemptFeeCacheKey struct {
+	calldataHash [sha256.Size]byte
+	txType       int
+	toAddress    common.Address
+	feeLimit     uint32
+	maxFeePrice  string
+}
+
+type attemptFeeCacheEntry struct {
+	fee         gas.EvmFee
+	feeLimit    uint32
+	estimatedAt time.Time
 }
 
 type evmTxAttemptBuilderFeeConfig interface {
 	EIP1559DynamicFees() bool
+	BumpMin() *assets.Wei
+	LimitDefault() uint32
+	TipCapDefault() *assets.Wei
 	TipCapMin() *assets.Wei
 	PriceMin() *assets.Wei
 	PriceMaxKey(common.Address) *assets.Wei
+	MinBumpPercent() uint16
+	TipOnlyDynamicFees() bool
+	TipOnlyBaseFeeMultiplier() uint16
+	MandatoryBaseFee() *assets.Wei
+	MaxPayloadBytes() uint32
+	UpgradeLegacyOnBump() bool
+	BumpTipOnly() bool
+	MaxPriorAttempts() uint32
+	MinFeeCapTipMarginWei() *assets.Wei
+	BumpGasLimitPercent() uint16
+	BumpGasLimitMax() uint32
+	MaxTotalCostWei() *assets.Wei
+	BumpJitterBasisPoints() uint16
+	StripAccessListOnBump() bool
 }
 
 func NewEvmTxAttemptBuilder(chainID big.Int, feeConfig evmTxAttemptBuilderFeeConfig, keystore TxAttemptSigner[common.Address], estimator gas.EvmFeeEstimator) *evmTxAttemptBuilder {
-	return &evmTxAttemptBuilder{chainID, feeConfig, keystore, estimator}
+	return &evmTxAttemptBuilder{
+		chainID:         chainID,
+		feeConfig:       feeConfig,
+		keystore:        keystore,
+		EvmFeeEstimator: estimator,
+		signatureScheme: EvmSignatureScheme{},
+		metrics:         &promAttemptBuilderMetrics{chainID: chainID.String()},
+		idempotencyKeys: make(map[string]time.Time),
+		feeCache:        make(map[attemptFeeCacheKey]attemptFeeCacheEntry),
+		signerBreakers:  make(map[common.Address]*signerBreaker),
+
+		assumptionViolationFatal: true,
+		idempotencyKeyRetention:  NoIdempotencyKeyExpiry,
+		now:                      time.Now,
+	}
+}
+
+// ChainID returns the chain ID the builder signs attempts for. The result is a copy, so mutating it has no effect
+// on the builder.
+func (c *evmTxAttemptBuilder) ChainID() big.Int {
+	return *new(big.Int).Set(&c.chainID)
+}
+
+// BuilderConfigSnapshot is the effective fee config used by the attempt builder, for debugging
+type BuilderConfigSnapshot struct {
+	EIP1559DynamicFees bool
+	TipCapMin          *assets.Wei
+	PriceMin           *assets.Wei
+	PriceMaxKey        *assets.Wei
+}
+
+// ConfigSnapshot returns the fee config the builder is using, with the max gas price resolved for addr
+func (c *evmTxAttemptBuilder) ConfigSnapshot(addr common.Address) BuilderConfigSnapshot {
+	return BuilderConfigSnapshot{
+		EIP1559DynamicFees: c.feeConfig.EIP1559DynamicFees(),
+		TipCapMin:          c.feeConfig.TipCapMin(),
+		PriceMin:           c.feeConfig.PriceMin(),
+		PriceMaxKey:        c.feeConfig.PriceMaxKey(addr),
+	}
+}
+
+// SetMetrics overrides where attempt build metrics are recorded, which defaults to prometheus
+func (c *evmTxAttemptBuilder) SetMetrics(metrics AttemptBuilderMetrics) {
+	c.metrics = metrics
+}
+
+// SetSignatureScheme overrides the scheme used to sign attempts, which defaults to standard EVM signing
+func (c *evmTxAttemptBuilder) SetSignatureScheme(scheme SignatureScheme) {
+	c.signatureScheme = scheme
+}
+
+// SetNonceProvider sets the source of nonces for txs that do not have a sequence assigned
+func (c *evmTxAttemptBuilder) SetNonceProvider(provider NonceProvider) {
+	c.nonceProvider = provider
+}
+
+// SetFeeEstimationTimeout bounds each call to the fee estimator, so a slow RPC cannot stall attempt construction.
+// Zero, the default, disables the timeout.
+func (c *evmTxAttemptBuilder) SetFeeEstimationTimeout(timeout time.Duration) {
+	c.feeEstimationTimeout = timeout
+}
+
+// SetStrictEncoding enables checking that every signed tx survives an RLP decode round-trip with its hash intact,
+// to catch encoding bugs before broadcast. Off by default.
+func (c *evmTxAttemptBuilder) SetStrictEncoding(strict bool) {
+	c.strictEncoding = strict
+}
+
+// SetBuildDeterministic requires the keystore to be a DeterministicTxAttemptSigner, so that attempts built from the same
+// tx, fee and fee limit are byte-identical, e.g. for golden-file tests. Signing fails with any other signer. Off by default.
+func (c *evmTxAttemptBuilder) SetBuildDeterministic(deterministic bool) {
+	c.buildDeterministic = deterministic
+}
+
+// SetVerifySender enables checking that the sender recovered from every signed attempt is the tx's from address,
+// failing the build with ErrSenderMismatch otherwise. Off by default, as it costs a signature recovery per attempt.
+func (c *evmTxAttemptBuilder) SetVerifySender(verify bool) {
+	c.verifySender = verify
+}
+
+// SetAssumptionViolationFatal controls how invariant failures while building attempts, e.g. an estimator returning
+// a fee of the wrong type, are logged. If true, the default, they are logged as assumption violations, which may page
+// on-call in some deployments. If false, they are logged as plain errors. The error is returned either way.
+func (c *evmTxAttemptBuilder) SetAssumptionViolationFatal(fatal bool) {
+	c.assumptionViolationFatal = fatal
+}
+
+// assumptionViolation logs err according to the assumptionViolationFatal setting
+func (c *evmTxAttemptBuilder) assumptionViolation(lggr logger.Logger, err error) {
+	if c.assumptionViolationFatal {
+		logger.Sugared(lggr).AssumptionViolation(err.Error())
+		return
+	}
+	lggr.Errorw("Invariant violated while building tx attempt", "err", err)
+}
+
+// SetSignerCircuitBreaker stops signing for a key for cooldown after threshold consecutive signing failures, e.g. so a
+// failed HSM-backed key is not hammered with requests. Builds for the key fail with ErrSignerUnavailable until the
+// cooldown expires. The next signing attempt is then let through: a success closes the breaker, and a failure opens it
+// for another cooldown. Zero threshold, the default, disables the breaker.
+func (c *evmTxAttemptBuilder) SetSignerCircuitBreaker(threshold int, cooldown time.Duration) {
+	c.signerBreakersMu.Lock()
+	defer c.signerBreakersMu.Unlock()
+	c.signerBreakerThreshold = threshold
+	c.signerBreakerCooldown = cooldown
+	c.signerBreakers = make(map[common.Address]*signerBreaker)
+}
+
+// checkSignerBreaker returns ErrSignerUnavailable if the breaker for address is open
+func (c *evmTxAttemptBuilder) checkSignerBreaker(address common.Address) error {
+	c.signerBreakersMu.Lock()
+	defer c.signerBreakersMu.Unlock()
+	if b, ok := c.signerBreakers[address]; ok && time.Now().Before(b.openUntil) {
+		return pkgerrors.Wrapf(ErrSignerUnavailable, "%d consecutive signing failures for %s, retrying after %s", b.failures, address, b.openUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// recordSignerResult resets the breaker for address on success, and opens it once failures reach the threshold.
+// Context errors are not counted, as a shutdown or caller timeout says nothing about the health of the signer.
+func (c *evmTxAttemptBuilder) recordSignerResult(address common.Address, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	c.signerBreakersMu.Lock()
+	defer c.signerBreakersMu.Unlock()
+	if c.signerBreakerThreshold <= 0 {
+		return
+	}
+	if err == nil {
+		delete(c.signerBreakers, address)
+		return
+	}
+	b, ok := c.signerBreakers[address]
+	if !ok {
+		b = &signerBreaker{}
+		c.signerBreakers[address] = b
+	}
+	b.failures++
+	if b.failures >= c.signerBreakerThreshold {
+		b.openUntil = time.Now().Add(c.signerBreakerCooldown)
+	}
+}
+
+// FeeDecision describes the fee chosen for an attempt built from an estimate
+type FeeDecision struct {
+	TxID   int64
+	TxType int
+	// Bump is set for NewBumpTxAttempt decisions, in which case PreviousFee is the fee of the attempt being bumped
+	Bump        bool
+	PreviousFee gas.EvmFee
+	// RequestedFeeLimit is the fee limit of the tx, before the estimator applied any multiplier
+	RequestedFeeLimit uint32
+	Fee               gas.EvmFee
+	FeeLimit          uint32
+	// FeeCapped is set if the fee was capped at the max gas price for the key
+	FeeCapped bool
+}
+
+// FeeDecisionObserver is notified after each attempt successfully built from a GetFee or BumpFee estimate, e.g. to
+// audit fees or collect data for tuning. Attempts built with a pinned or custom fee are not observed.
+type FeeDecisionObserver interface {
+	ObserveFee(decision FeeDecision)
+}
+
+// SetFeeDecisionObserver sets the observer notified of fee decisions, nil disables it
+func (c *evmTxAttemptBuilder) SetFeeDecisionObserver(observer FeeDecisionObserver) {
+	c.feeDecisionObserver = observer
+}
+
+// observeFee notifies the fee decision observer, if any
+func (c *evmTxAttemptBuilder) observeFee(decision FeeDecision) {
+	if c.feeDecisionObserver != nil {
+		c.feeDecisionObserver.ObserveFee(decision)
+	}
+}
+
+// GasUsedHistory reports the gas used by recently confirmed txs, e.g. from their receipts
+type GasUsedHistory interface {
+	// GasUsedPercentile returns the percentile (0-100) of gas used by recent txs to toAddress calling the function
+	// with the given selector. ok is false if there is not enough history.
+	GasUsedPercentile(toAddress common.Address, selector [4]byte, percentile float64) (gasUsed uint32, ok bool)
+}
+
+// SetGasUsedHistory makes new attempts use the given percentile of recent gas used by similar calls, plus
+// marginPercent, as their fee limit instead of the tx's fee limit. Txs without history keep their own fee limit.
+// A nil history, the default, disables this.
+func (c *evmTxAttemptBuilder) SetGasUsedHistory(history GasUsedHistory, percentile float64, marginPercent uint16) {
+	c.gasUsedHistory = history
+	c.gasUsedPercentile = percentile
+	c.gasUsedMarginPercent = marginPercent
+}
+
+// feeLimitFromHistory returns the fee limit sized from the gas used history, if configured and it has history for etx
+func (c *evmTxAttemptBuilder) feeLimitFromHistory(etx Tx) (uint32, bool) {
+	if c.gasUsedHistory == nil {
+		return 0, false
+	}
+	var selector [4]byte
+	copy(selector[:], etx.EncodedPayload)
+	gasUsed, ok := c.gasUsedHistory.GasUsedPercentile(etx.ToAddress, selector, c.gasUsedPercentile)
+	if !ok || gasUsed == 0 {
+		return 0, false
+	}
+	limit := uint64(gasUsed) + uint64(gasUsed)*uint64(c.gasUsedMarginPercent)/100
+	return uint32(min(limit, math.MaxUint32)), true
+}
+
+// BumpBroadcastFunc broadcasts a bumped attempt, so that NewBumpTxAttempt can react to the node's response without
+// the builder depending on a client
+type BumpBroadcastFunc func(ctx context.Context, attempt TxAttempt) error
+
+// SetBumpBroadcast makes NewBumpTxAttempt broadcast each bumped attempt. If the node rejects it as replacement
+// underpriced, the bump over the previous fee is doubled, capped by the max gas price for the key, and the attempt is
+// rebuilt and broadcast again, up to maxRetries times. A nil broadcast, the default, disables this.
+func (c *evmTxAttemptBuilder) SetBumpBroadcast(broadcast BumpBroadcastFunc, maxRetries int) {
+	c.bumpBroadcast = broadcast
+	c.bumpBroadcastRetries = maxRetries
+}
+
+// SetIdempotencyKeyRetention sets how long BuildWithIdempotencyKey rejects a key after building an attempt for it.
+// By default keys are never forgotten, see NoIdempotencyKeyExpiry. A finite retention bounds the memory used to track
+// keys on long-running nodes, at the cost of accepting a key again once its attempt is older than the retention.
+// Zero disables duplicate detection, and keys are no longer tracked.
+func (c *evmTxAttemptBuilder) SetIdempotencyKeyRetention(retention time.Duration) {
+	c.idempotencyKeysMu.Lock()
+	defer c.idempotencyKeysMu.Unlock()
+	c.idempotencyKeyRetention = retention
+	if retention == 0 {
+		c.idempotencyKeys = make(map[string]time.Time)
+	}
+}
+
+// SetClock overrides the clock used to expire idempotency keys, time.Now by default
+func (c *evmTxAttemptBuilder) SetClock(now func() time.Time) {
+	c.idempotencyKeysMu.Lock()
+	defer c.idempotencyKeysMu.Unlock()
+	c.now = now
+}
+
+// SetFeeCacheTTL enables reuse of estimated fees for txs with identical calldata, fee limit and max price, for
+// up to ttl or until the next head, whichever comes first. Useful on chains with slow fee endpoints.
+// Zero, the default, disables the cache.
+func (c *evmTxAttemptBuilder) SetFeeCacheTTL(ttl time.Duration) {
+	c.feeCacheMu.Lock()
+	defer c.feeCacheMu.Unlock()
+	c.feeCacheTTL = ttl
+	c.feeCache = make(map[attemptFeeCacheKey]attemptFeeCacheEntry)
+}
+
+// OnNewLongestChain invalidates the fee cache, since cached fees are only valid within a block, and passes the head on to the estimator.
+// If head does not extend the previous head, the estimator is also notified of the reorg.
+func (c *evmTxAttemptBuilder) OnNewLongestChain(ctx context.Context, head *evmtypes.Head) {
+	c.feeCacheMu.Lock()
+	c.feeCache = make(map[attemptFeeCacheKey]attemptFeeCacheEntry)
+	c.feeCacheMu.Unlock()
+
+	c.lastHeadMu.Lock()
+	prev := c.lastHead
+	c.lastHead = head
+	c.lastHeadMu.Unlock()
+	if prev != nil && !head.IsInChain(prev.Hash) {
+		c.EvmFeeEstimator.OnReorg(ctx, head, commonAncestor(prev, head))
+	}
+	c.EvmFeeEstimator.OnNewLongestChain(ctx, head)
+}
+
+// commonAncestor returns the latest head in prev's chain that is also in head's chain, or nil if none is known
+func commonAncestor(prev, head *evmtypes.Head) *evmtypes.Head {
+	for h := prev; h != nil; h = h.Parent {
+		if head.HashAtHeight(h.Number) == h.Hash {
+			return h
+		}
+	}
+	return nil
+}
+
+// SetBaseFeeSource sets the source of the latest base fee, needed to build dynamic fee attempts from
+// tip-only fees when the fee config enables TipOnlyDynamicFees
+func (c *evmTxAttemptBuilder) SetBaseFeeSource(source BaseFeeSource) {
+	c.baseFeeSource = source
+}
+
+// SetL1OracleOverride sets an L1 oracle to be used for transactions flagged with TxMeta.L1OracleOverride,
+// e.g. to price large calldata posts with a more conservative L1 fee than routine transactions
+func (c *evmTxAttemptBuilder) SetL1OracleOverride(l1Oracle rollups.L1Oracle) {
+	c.l1OracleOverride = l1Oracle
+}
+
+// L1OracleForTx returns the L1 oracle that should be used to price the given transaction.
+// This is the override oracle if one is set and the tx is flagged for it, otherwise the estimator's L1 oracle.
+func (c *evmTxAttemptBuilder) L1OracleForTx(etx Tx, lggr logger.Logger) rollups.L1Oracle {
+	if c.l1OracleOverride != nil {
+		meta, err := etx.GetMeta()
+		if err != nil {
+			lggr.Errorw("Failed to get tx meta, falling back to default L1 oracle", "txID", etx.ID, "err", err)
+		} else if meta != nil && meta.L1OracleOverride {
+			return c.l1OracleOverride
+		}
+	}
+	if c.EvmFeeEstimator == nil {
+		return nil
+	}
+	return c.EvmFeeEstimator.L1Oracle()
+}
+
+// SupportedTxTypes returns the tx types the builder can build attempts for with the current fee config,
+// so callers can validate a tx type before requesting an attempt
+func (c *evmTxAttemptBuilder) SupportedTxTypes() []int {
+	txTypes := []int{0x0}
+	if c.feeConfig.EIP1559DynamicFees() {
+		txTypes = append(txTypes, 0x2)
+	}
+	return txTypes
+}
+
+// CheckSignerReady confirms the keystore can sign for the given address, by signing and discarding a zero value tx
+func (c *evmTxAttemptBuilder) CheckSignerReady(ctx context.Context, address common.Address) error {
+	tx := types.NewTx(&types.LegacyTx{To: &address, Value: big.NewInt(0), GasPrice: big.NewInt(0)})
+	if _, err := signTxWith(ctx, c.keystore, address, tx, &c.chainID); err != nil {
+		return pkgerrors.Wrapf(err, "signer not ready for address %s", address)
+	}
+	return nil
+}
+
+// SignerHealthReport returns the result of CheckSignerReady for each of the given addresses
+func (c *evmTxAttemptBuilder) SignerHealthReport(ctx context.Context, addresses ...common.Address) map[string]error {
+	report := make(map[string]error, len(addresses))
+	for _, address := range addresses {
+		report[fmt.Sprintf("EvmTxAttemptBuilder.Signer(%s)", address)] = c.CheckSignerReady(ctx, address)
+	}
+	return report
 }
 
 // NewTxAttempt builds an new attempt using the configured fee estimator + using the EIP1559 config to determine tx type
@@ -49,81 +616,673 @@ func (c *evmTxAttemptBuilder) NewTxAttempt(ctx context.Context, etx Tx, lggr log
 	if c.feeConfig.EIP1559DynamicFees() {
 		txType = 0x2
 	}
-	return c.NewTxAttemptWithType(ctx, etx, lggr, txType, opts...)
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewTxAttempt", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	if txType == 0x2 && !c.EvmFeeEstimator.SupportsEIP1559() {
+		// the config and estimator disagree, which retrying cannot fix
+		return attempt, fee, feeLimit, false, pkgerrors.Wrapf(ErrEIP1559NotSupported, "EIP-1559 dynamic fees are enabled but the %s estimator does not support them", c.EvmFeeEstimator.Name())
+	}
+	return c.newTxAttemptWithType(ctx, etx, lggr, txType, opts...)
 }
 
 // NewTxAttemptWithType builds a new attempt with a new fee estimation where the txType can be specified by the caller
 // used for L2 re-estimation on broadcasting (note EIP1559 must be disabled otherwise this will fail with mismatched fees + tx type)
 func (c *evmTxAttemptBuilder) NewTxAttemptWithType(ctx context.Context, etx Tx, lggr logger.Logger, txType int, opts ...feetypes.Opt) (attempt TxAttempt, fee gas.EvmFee, feeLimit uint32, retryable bool, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewTxAttemptWithType", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	return c.newTxAttemptWithType(ctx, etx, lggr, txType, opts...)
+}
+
+func (c *evmTxAttemptBuilder) newTxAttemptWithType(ctx context.Context, etx Tx, lggr logger.Logger, txType int, opts ...feetypes.Opt) (attempt TxAttempt, fee gas.EvmFee, feeLimit uint32, retryable bool, err error) {
+	// don't block shutdown waiting on a potentially slow estimator
+	if err = ctx.Err(); err != nil {
+		return attempt, fee, feeLimit, true, pkgerrors.Wrap(err, "failed to get fee")
+	}
+	if etx.FeeLimit == 0 {
+		// the estimator may still cap the default
+		etx.FeeLimit = c.feeConfig.LimitDefault()
+		lggr.Debugw("Tx has no fee limit, using default", "txID", etx.ID, "limitDefault", etx.FeeLimit)
+	}
+	if limit, ok := c.feeLimitFromHistory(etx); ok {
+		lggr.Debugw("Using fee limit sized from gas used history", "txID", etx.ID, "feeLimit", limit, "txFeeLimit", etx.FeeLimit)
+		etx.FeeLimit = limit
+	}
+	if exactFee, ok := feetypes.ExactFee[gas.EvmFee](opts); ok {
+		lggr.Debugw("Using pinned fee for new attempt", append([]interface{}{"txID", etx.ID, "txType", txType, "feeLimit", etx.FeeLimit}, exactFee.LoggerFields()...)...)
+		attempt, retryable, err = c.newCustomTxAttempt(ctx, etx, exactFee, etx.FeeLimit, txType, nil, nil, lggr, opts...)
+		return attempt, exactFee, etx.FeeLimit, retryable, err
+	}
 	keySpecificMaxGasPriceWei := c.feeConfig.PriceMaxKey(etx.FromAddress)
-	fee, feeLimit, err = c.EvmFeeEstimator.GetFee(ctx, etx.EncodedPayload, etx.FeeLimit, keySpecificMaxGasPriceWei, opts...)
+	feeCtx, cancel := c.feeEstimationContext(ctx)
+	defer cancel()
+	fee, feeLimit, err = c.getFeeForType(feeCtx, txType, etx, keySpecificMaxGasPriceWei, opts...)
 	if err != nil {
-		return attempt, fee, feeLimit, true, errors.Wrap(err, "failed to get fee") // estimator errors are retryable
+		return attempt, fee, feeLimit, true, c.wrapEstimatorError(ctx, feeCtx, err, "failed to get fee") // estimator errors are retryable
+	}
+	fields := append([]interface{}{"txID", etx.ID, "txType", txType, "feeLimit", feeLimit}, fee.LoggerFields()...)
+	if fee.Stale {
+		lggr.Warnw("Fee estimator is failing, using last known good fee", fields...)
+	} else {
+		lggr.Debugw("Estimated fee for new attempt", fields...)
+	}
+
+	attempt, retryable, err = c.newCustomTxAttempt(ctx, etx, fee, feeLimit, txType, nil, nil, lggr, opts...)
+	if err == nil {
+		c.observeFee(FeeDecision{TxID: etx.ID, TxType: txType, RequestedFeeLimit: etx.FeeLimit, Fee: fee, FeeLimit: feeLimit, FeeCapped: attempt.FeeCapped})
+	}
+	return attempt, fee, feeLimit, retryable, err
+}
+
+// NoIdempotencyKeyExpiry is the default idempotency key retention, which remembers keys for the builder's lifetime
+const NoIdempotencyKeyExpiry time.Duration = math.MaxInt64
+
+// BuildWithIdempotencyKey builds a new attempt like NewTxAttempt, but rejects the tx with ErrDuplicateIdempotencyKey if
+// an attempt has already been built for its idempotency key by this builder. Keys are tracked in memory for the
+// builder's lifetime, unless a retention is set with SetIdempotencyKeyRetention. The key is released if the build
+// fails, so that it can be retried.
+func (c *evmTxAttemptBuilder) BuildWithIdempotencyKey(ctx context.Context, etx Tx, lggr logger.Logger, opts ...feetypes.Opt) (attempt TxAttempt, fee gas.EvmFee, feeLimit uint32, retryable bool, err error) {
+	if etx.IdempotencyKey == nil {
+		return attempt, fee, feeLimit, false, pkgerrors.Errorf("cannot build attempt for tx %v: tx has no idempotency key", etx.ID)
+	}
+	key := *etx.IdempotencyKey
+	c.idempotencyKeysMu.Lock()
+	if c.idempotencyKeyRetention == 0 {
+		c.idempotencyKeysMu.Unlock()
+		return c.NewTxAttempt(ctx, etx, lggr, opts...)
 	}
+	now := c.now()
+	c.pruneIdempotencyKeys(now)
+	if builtAt, exists := c.idempotencyKeys[key]; exists && now.Sub(builtAt) < c.idempotencyKeyRetention {
+		c.idempotencyKeysMu.Unlock()
+		return attempt, fee, feeLimit, false, pkgerrors.Wrapf(ErrDuplicateIdempotencyKey, "cannot build attempt for tx %v with key %s", etx.ID, key)
+	}
+	c.idempotencyKeys[key] = now
+	c.idempotencyKeysMu.Unlock()
 
-	attempt, retryable, err = c.NewCustomTxAttempt(etx, fee, feeLimit, txType, lggr)
+	attempt, fee, feeLimit, retryable, err = c.NewTxAttempt(ctx, etx, lggr, opts...)
+	if err != nil {
+		c.idempotencyKeysMu.Lock()
+		delete(c.idempotencyKeys, key)
+		c.idempotencyKeysMu.Unlock()
+	}
 	return attempt, fee, feeLimit, retryable, err
 }
 
+// pruneIdempotencyKeys removes keys older than the retention window, at most once per window, so that each call stays
+// cheap. Must be called with idempotencyKeysMu held.
+func (c *evmTxAttemptBuilder) pruneIdempotencyKeys(now time.Time) {
+	if c.idempotencyKeyRetention == NoIdempotencyKeyExpiry || now.Sub(c.idempotencyKeysPrunedAt) < c.idempotencyKeyRetention {
+		return
+	}
+	for key, builtAt := range c.idempotencyKeys {
+		if now.Sub(builtAt) >= c.idempotencyKeyRetention {
+			delete(c.idempotencyKeys, key)
+		}
+	}
+	c.idempotencyKeysPrunedAt = now
+}
+
+// getFeeForType gets a fee from the estimator, serving it from the fee cache if enabled.
+// OptForceRefetch bypasses the cache. Opts carrying a value, e.g. WithBaseFee, change the estimate and are not part of
+// the cache key, so they bypass the cache too.
+func (c *evmTxAttemptBuilder) getFeeForType(ctx context.Context, txType int, etx Tx, maxFeePrice *assets.Wei, opts ...feetypes.Opt) (gas.EvmFee, uint32, error) {
+	c.feeCacheMu.Lock()
+	ttl := c.feeCacheTTL
+	c.feeCacheMu.Unlock()
+	if ttl <= 0 || feetypes.HasValueOpt(opts) {
+		return c.EvmFeeEstimator.GetFeeForType(ctx, txType, etx.ToAddress, etx.EncodedPayload, etx.FeeLimit, maxFeePrice, opts...)
+	}
+
+	key := attemptFeeCacheKey{
+		calldataHash: sha256.Sum256(etx.EncodedPayload),
+		txType:       txType,
+		toAddress:    etx.ToAddress,
+		feeLimit:     etx.FeeLimit,
+		maxFeePrice:  maxFeePrice.String(),
+	}
+	if !slices.Contains(opts, feetypes.OptForceRefetch) {
+		c.feeCacheMu.Lock()
+		entry, ok := c.feeCache[key]
+		c.feeCacheMu.Unlock()
+		if ok && time.Since(entry.estimatedAt) <= ttl {
+			return entry.fee, entry.feeLimit, nil
+		}
+	}
+
+	fee, feeLimit, err := c.EvmFeeEstimator.GetFeeForType(ctx, txType, etx.ToAddress, etx.EncodedPayload, etx.FeeLimit, maxFeePrice, opts...)
+	// stale fees are already a fallback and are not worth keeping
+	if err == nil && !fee.Stale {
+		c.feeCacheMu.Lock()
+		c.feeCache[key] = attemptFeeCacheEntry{fee: fee, feeLimit: feeLimit, estimatedAt: time.Now()}
+		c.feeCacheMu.Unlock()
+	}
+	return fee, feeLimit, err
+}
+
 // NewBumpTxAttempt builds a new attempt with a bumped fee - based on the previous attempt tx type
 // used in the txm broadcaster + confirmer when tx ix rejected for too low fee or is not included in a timely manner
 func (c *evmTxAttemptBuilder) NewBumpTxAttempt(ctx context.Context, etx Tx, previousAttempt TxAttempt, priorAttempts []TxAttempt, lggr logger.Logger) (attempt TxAttempt, bumpedFee gas.EvmFee, bumpedFeeLimit uint32, retryable bool, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewBumpTxAttempt", previousAttempt.TxType, time.Since(start), err, retryable)
+	}(time.Now())
+	if err = ctx.Err(); err != nil {
+		return attempt, bumpedFee, bumpedFeeLimit, true, pkgerrors.Wrap(err, "failed to bump fee")
+	}
 	keySpecificMaxGasPriceWei := c.feeConfig.PriceMaxKey(etx.FromAddress)
 
-	bumpedFee, bumpedFeeLimit, err = c.EvmFeeEstimator.BumpFee(ctx, previousAttempt.TxFee, etx.FeeLimit, keySpecificMaxGasPriceWei, newEvmPriorAttempts(priorAttempts))
+	txType, previousFee := previousAttempt.TxType, previousAttempt.TxFee
+	evmPriorAttempts := newEvmPriorAttempts(c.recentPriorAttempts(priorAttempts), lggr)
+	if c.upgradeLegacyOnBump() && txType == 0x0 && previousFee.ValidLegacy() {
+		lggr.Infow("Upgrading legacy attempt to a dynamic fee attempt on bump", "txID", etx.ID, "previousGasPrice", previousFee.Legacy)
+		txType, previousFee = 0x2, c.legacyToDynamicFee(previousFee.Legacy)
+		for i := range evmPriorAttempts {
+			if evmPriorAttempts[i].TxType == 0x0 && evmPriorAttempts[i].GasPrice != nil {
+				fee := c.legacyToDynamicFee(evmPriorAttempts[i].GasPrice)
+				evmPriorAttempts[i].TxType = 0x2
+				evmPriorAttempts[i].DynamicFee = gas.DynamicFee{FeeCap: fee.DynamicFeeCap, TipCap: fee.DynamicTipCap}
+			}
+		}
+	}
+
+	// decided before estimating, so the estimator keeps the fee cap as is rather than failing to bump it near the max price
+	tipOnly := c.feeConfig.BumpTipOnly() && txType == 0x2 && previousFee.DynamicFeeCap != nil
+	var bumpOpts []feetypes.Opt
+	if tipOnly {
+		bumpOpts = append(bumpOpts, feetypes.OptBumpTipOnly)
+	}
+	feeCtx, cancel := c.feeEstimationContext(ctx)
+	defer cancel()
+	bumpedFee, bumpedFeeLimit, err = c.EvmFeeEstimator.BumpFee(feeCtx, previousFee, etx.FeeLimit, keySpecificMaxGasPriceWei, evmPriorAttempts, bumpOpts...)
 	if err != nil {
-		return attempt, bumpedFee, bumpedFeeLimit, true, errors.Wrap(err, "failed to bump fee") // estimator errors are retryable
+		return attempt, bumpedFee, bumpedFeeLimit, true, c.wrapEstimatorError(ctx, feeCtx, err, "failed to bump fee") // estimator errors are retryable
+	}
+	if tipOnly {
+		bumpedFee.DynamicFeeCap = previousFee.DynamicFeeCap
 	}
+	if bumpedFee, err = c.enforceMinBump(etx, previousFee, bumpedFee, tipOnly, keySpecificMaxGasPriceWei, lggr); err != nil {
+		return attempt, bumpedFee, bumpedFeeLimit, true, err
+	}
+	// jitter goes last, so keys bumped to the minimum replacement fee are still spread out rather than all raised to it
+	bumpedFee = c.jitterBumpedFee(etx, previousFee, bumpedFee, tipOnly, keySpecificMaxGasPriceWei, lggr)
+	bumpedFeeLimit = c.bumpGasLimit(previousAttempt.ChainSpecificFeeLimit, bumpedFeeLimit)
+	lggr.Debugw("Bumped fee for new attempt", append([]interface{}{"txID", etx.ID, "previousFee", previousFee.String(), "txType", txType, "feeLimit", bumpedFeeLimit}, bumpedFee.LoggerFields()...)...)
 
-	attempt, retryable, err = c.NewCustomTxAttempt(etx, bumpedFee, bumpedFeeLimit, previousAttempt.TxType, lggr)
+	accessList := c.bumpAccessList(previousAttempt, txType, lggr)
+	attempt, retryable, err = c.newCustomTxAttempt(ctx, etx, bumpedFee, bumpedFeeLimit, txType, nil, accessList, lggr)
+	if err == nil && c.bumpBroadcast != nil {
+		attempt, bumpedFee, retryable, err = c.broadcastBump(ctx, etx, txType, previousFee, attempt, bumpedFee, bumpedFeeLimit, accessList, keySpecificMaxGasPriceWei, lggr)
+	}
+	if err == nil {
+		c.observeFee(FeeDecision{TxID: etx.ID, TxType: txType, Bump: true, PreviousFee: previousFee, RequestedFeeLimit: etx.FeeLimit, Fee: bumpedFee, FeeLimit: bumpedFeeLimit, FeeCapped: attempt.FeeCapped})
+	}
 	return attempt, bumpedFee, bumpedFeeLimit, retryable, err
 }
 
+// broadcastBump broadcasts the bumped attempt, doubling the bump over the previous fee and rebuilding the attempt each
+// time the node rejects it as replacement underpriced. Other errors are returned as is, and are only retryable if
+// retrying could succeed, see isRetryableSendError.
+func (c *evmTxAttemptBuilder) broadcastBump(ctx context.Context, etx Tx, txType int, previousFee gas.EvmFee, attempt TxAttempt, bumpedFee gas.EvmFee, feeLimit uint32, accessList types.AccessList, maxFeePrice *assets.Wei, lggr logger.Logger) (TxAttempt, gas.EvmFee, bool, error) {
+	for retry := 0; ; retry++ {
+		err := c.bumpBroadcast(ctx, attempt)
+		if err == nil {
+			return attempt, bumpedFee, true, nil
+		}
+		sendErr := evmclient.NewSendError(err)
+		if !sendErr.IsReplacementUnderpriced() {
+			return attempt, bumpedFee, isRetryableSendError(sendErr), pkgerrors.Wrap(err, "failed to broadcast bumped attempt")
+		}
+		if retry >= c.bumpBroadcastRetries {
+			return attempt, bumpedFee, true, pkgerrors.Wrapf(err, "bumped attempt still underpriced after %d retries", retry)
+		}
+		next := gas.EvmFee{
+			Legacy:        growBump(previousFee.Legacy, bumpedFee.Legacy, maxFeePrice),
+			DynamicFeeCap: growBump(previousFee.DynamicFeeCap, bumpedFee.DynamicFeeCap, maxFeePrice),
+			DynamicTipCap: growBump(previousFee.DynamicTipCap, bumpedFee.DynamicTipCap, maxFeePrice),
+		}
+		if !next.GreaterThan(bumpedFee) {
+			return attempt, bumpedFee, false, pkgerrors.Wrapf(commonfee.ErrBumpFeeExceedsLimit, "bumped attempt is underpriced at %s and cannot be bumped further without exceeding max configured gas price of %s for key %s",
+				bumpedFee.String(), maxFeePrice, etx.FromAddress)
+		}
+		lggr.Warnw("Bumped attempt rejected as replacement underpriced, retrying with a larger bump", "txID", etx.ID, "retry", retry+1, "previousFee", bumpedFee.String(), "fee", next.String())
+		bumpedFee = next
+		var retryable bool
+		if attempt, retryable, err = c.newCustomTxAttempt(ctx, etx, bumpedFee, feeLimit, txType, nil, accessList, lggr); err != nil {
+			return attempt, bumpedFee, retryable, err
+		}
+	}
+}
+
+// isRetryableSendError returns false for send errors that no retry will fix: errors the node flags as fatal, a sequence
+// that has already been used, a key that is out of funds, or a fee over the node's fee cap
+func isRetryableSendError(sendErr *evmclient.SendError) bool {
+	return !sendErr.Fatal() && !sendErr.IsNonceTooLowError() && !sendErr.IsTransactionAlreadyMined() &&
+		!sendErr.IsInsufficientEth() && !sendErr.IsTxFeeExceedsCap()
+}
+
+// growBump doubles the increase of bumped over previous, capped at max
+func growBump(previous, bumped, max *assets.Wei) *assets.Wei {
+	if previous == nil || bumped == nil || bumped.Cmp(previous) <= 0 {
+		return bumped
+	}
+	return assets.WeiMin(bumped.Add(bumped.Sub(previous)), max)
+}
+
+// bumpAccessList returns the access list signed into the previous attempt, to carry over to its bumped replacement.
+// Returns nil if StripAccessListOnBump is enabled, or if the bumped attempt is not a dynamic fee tx.
+func (c *evmTxAttemptBuilder) bumpAccessList(previousAttempt TxAttempt, txType int, lggr logger.Logger) types.AccessList {
+	if c.feeConfig.StripAccessListOnBump() || txType != 0x2 || len(previousAttempt.SignedRawTx) == 0 {
+		return nil
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(previousAttempt.SignedRawTx); err != nil {
+		lggr.Warnw("Failed to decode previous attempt, bumping without its access list", "attemptID", previousAttempt.ID, "err", err)
+		return nil
+	}
+	return tx.AccessList()
+}
+
+// upgradeLegacyOnBump returns true if UpgradeLegacyOnBump is enabled while EIP-1559 is enabled, for chains that
+// activated EIP-1559 while legacy txs were still pending
+func (c *evmTxAttemptBuilder) upgradeLegacyOnBump() bool {
+	return c.feeConfig.UpgradeLegacyOnBump() && c.feeConfig.EIP1559DynamicFees()
+}
+
+// jitterBumpedFee scales each component of the bumped fee by an offset of up to ± BumpJitterBasisPoints, capped at
+// maxFeePrice. The offset is derived from the from address, so it is stable for a key but varies across keys, to stop
+// nodes sharing an estimator from all bumping to the same fee. A negative offset that would take any component below
+// the minimum replacement bump over the previous fee is applied as a positive one instead. With tipOnly, the fee cap
+// is left as is.
+func (c *evmTxAttemptBuilder) jitterBumpedFee(etx Tx, previousFee gas.EvmFee, bumpedFee gas.EvmFee, tipOnly bool, maxFeePrice *assets.Wei, lggr logger.Logger) gas.EvmFee {
+	bps := c.feeConfig.BumpJitterBasisPoints()
+	if bps == 0 {
+		return bumpedFee
+	}
+	offset := bumpJitterOffset(etx.FromAddress, bps)
+	apply := func(offset int64) gas.EvmFee {
+		scale := func(w *assets.Wei) *assets.Wei {
+			if w == nil {
+				return nil
+			}
+			scaled := new(big.Int).Mul(w.ToInt(), big.NewInt(10_000+offset))
+			scaled.Div(scaled, big.NewInt(10_000))
+			return assets.WeiMin(assets.NewWei(scaled), maxFeePrice)
+		}
+		jittered := gas.EvmFee{Legacy: scale(bumpedFee.Legacy), DynamicFeeCap: scale(bumpedFee.DynamicFeeCap), DynamicTipCap: scale(bumpedFee.DynamicTipCap)}
+		if tipOnly {
+			jittered.DynamicFeeCap = bumpedFee.DynamicFeeCap
+		}
+		return jittered
+	}
+	belowMin := func(previous, jittered, bumpMin *assets.Wei) bool {
+		return previous != nil && jittered != nil && jittered.Cmp(c.minReplacementFee(previous, bumpMin)) < 0
+	}
+	jittered := apply(offset)
+	if offset < 0 && (belowMin(previousFee.Legacy, jittered.Legacy, c.feeConfig.BumpMin()) ||
+		belowMin(previousFee.DynamicFeeCap, jittered.DynamicFeeCap, nil) || belowMin(previousFee.DynamicTipCap, jittered.DynamicTipCap, nil)) {
+		offset = -offset
+		jittered = apply(offset)
+	}
+	lggr.Debugw("Applied jitter to bumped fee", "txID", etx.ID, "offsetBasisPoints", offset, "bumpedFee", bumpedFee.String(), "jitteredFee", jittered.String())
+	return jittered
+}
+
+// bumpJitterOffset returns an offset in basis points in [-bps, bps], derived from the address
+func bumpJitterOffset(address common.Address, bps uint16) int64 {
+	h := sha256.Sum256(address.Bytes())
+	return int64(binary.BigEndian.Uint64(h[:8])%(2*uint64(bps)+1)) - int64(bps)
+}
+
+// checkMaxTotalCost returns ErrTotalCostExceedsMax if the most the tx could spend, its value plus the fee limit times
+// the gas price or fee cap, exceeds MaxTotalCostWei. A nil max disables this.
+func (c *evmTxAttemptBuilder) checkMaxTotalCost(etx Tx, price *assets.Wei, gasLimit uint32) error {
+	maxCost := c.feeConfig.MaxTotalCostWei()
+	if maxCost == nil {
+		return nil
+	}
+	cost := new(big.Int).Mul(price.ToInt(), new(big.Int).SetUint64(uint64(gasLimit)))
+	cost.Add(cost, etx.ValueBigInt())
+	if cost.Cmp(maxCost.ToInt()) > 0 {
+		return pkgerrors.Wrapf(ErrTotalCostExceedsMax, "cannot create tx attempt for tx %d: max cost of %s wei (value %s + fee limit %d x %s) exceeds max total cost of %s wei",
+			etx.ID, cost, etx.ValueBigInt(), gasLimit, price, maxCost.ToInt())
+	}
+	return nil
+}
+
+// bumpGasLimit raises the gas limit by BumpGasLimitPercent, for chains where a stuck tx needs more gas rather than
+// just a higher fee. The bump compounds over the previous attempt's limit, and is capped at BumpGasLimitMax if it is
+// non-zero. The estimator's limit is never lowered.
+func (c *evmTxAttemptBuilder) bumpGasLimit(previousLimit, limit uint32) uint32 {
+	pct := c.feeConfig.BumpGasLimitPercent()
+	if pct == 0 {
+		return limit
+	}
+	base := uint64(max(previousLimit, limit))
+	bumped := base + base*uint64(pct)/100
+	if maxLimit := c.feeConfig.BumpGasLimitMax(); maxLimit > 0 {
+		bumped = min(bumped, uint64(maxLimit))
+	}
+	bumped = min(bumped, math.MaxUint32)
+	return max(limit, uint32(bumped))
+}
+
+// legacyToDynamicFee converts a legacy gas price to a dynamic fee with the gas price as the fee cap, so the tx never
+// pays more than it would have as a legacy tx. The tip cap is derived from TipCapDefault, raised to TipCapMin and
+// capped at the gas price less MinFeeCapTipMarginWei, so the rest of the fee cap is left for the base fee.
+func (c *evmTxAttemptBuilder) legacyToDynamicFee(gasPrice *assets.Wei) gas.EvmFee {
+	tipCap := assets.WeiMax(c.feeConfig.TipCapDefault(), c.feeConfig.TipCapMin())
+	maxTipCap := gasPrice
+	if margin := c.feeConfig.MinFeeCapTipMarginWei(); margin != nil {
+		maxTipCap = assets.WeiMax(gasPrice.Sub(margin), assets.NewWeiI(0))
+	}
+	return gas.EvmFee{DynamicFeeCap: gasPrice, DynamicTipCap: assets.WeiMin(tipCap, maxTipCap)}
+}
+
+// feeEstimationContext derives a context bounded by the fee estimation timeout, if one is set
+func (c *evmTxAttemptBuilder) feeEstimationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.feeEstimationTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.feeEstimationTimeout)
+}
+
+// wrapEstimatorError wraps an estimator error, noting if it was caused by the fee estimation timeout rather than the caller's context
+func (c *evmTxAttemptBuilder) wrapEstimatorError(ctx, feeCtx context.Context, err error, msg string) error {
+	if ctx.Err() == nil && errors.Is(feeCtx.Err(), context.DeadlineExceeded) {
+		return pkgerrors.Wrapf(err, "%s: fee estimation timed out after %s", msg, c.feeEstimationTimeout)
+	}
+	return pkgerrors.Wrap(err, msg)
+}
+
+// DefaultMinBumpPercent is the minimum fee increase accepted by geth for a replacement transaction
+const DefaultMinBumpPercent uint16 = 10
+
+// minBumpPercent returns the minimum percentage a bumped fee must exceed the previous one by, which can be
+// overridden by MinBumpPercent
+func (c *evmTxAttemptBuilder) minBumpPercent() uint16 {
+	if pct := c.feeConfig.MinBumpPercent(); pct > 0 {
+		return pct
+	}
+	return DefaultMinBumpPercent
+}
+
+// enforceMinBump raises any component of the bumped fee that is below the minimum replacement threshold over the
+// previous fee up to that threshold, to avoid "replacement transaction underpriced" rejections.
+// The threshold is the min bump percentage, and for legacy gas prices at least BumpMin more than the previous price.
+// Errors if the threshold would exceed the max gas price for the key. With tipOnly, the fee cap is kept as is and only
+// the tip cap is checked.
+func (c *evmTxAttemptBuilder) enforceMinBump(etx Tx, previousFee gas.EvmFee, bumpedFee gas.EvmFee, tipOnly bool, maxFeePrice *assets.Wei, lggr logger.Logger) (gas.EvmFee, error) {
+	enforce := func(name string, previous, bumped, bumpMin *assets.Wei) (*assets.Wei, error) {
+		if previous == nil || bumped == nil {
+			return bumped, nil
+		}
+		min := c.minReplacementFee(previous, bumpMin)
+		if bumped.Cmp(min) >= 0 {
+			return bumped, nil
+		}
+		if min.Cmp(maxFeePrice) > 0 {
+			return bumped, pkgerrors.Wrapf(commonfee.ErrBumpFeeExceedsLimit, "bumped %s of %s is less than the minimum replacement bump to %s over %s, and bumping would exceed max configured gas price of %s for key %s",
+				name, bumped, min, previous, maxFeePrice, etx.FromAddress)
+		}
+		lggr.Debugw(fmt.Sprintf("Bumped %s is below the minimum replacement bump, raising it", name), "txID", etx.ID, "previous", previous, "bumped", bumped, "min", min)
+		return min, nil
+	}
+	var err error
+	if bumpedFee.Legacy, err = enforce("gas price", previousFee.Legacy, bumpedFee.Legacy, c.feeConfig.BumpMin()); err != nil {
+		return bumpedFee, err
+	}
+	if bumpedFee.DynamicTipCap, err = enforce("tip cap", previousFee.DynamicTipCap, bumpedFee.DynamicTipCap, nil); err != nil {
+		return bumpedFee, err
+	}
+	if tipOnly {
+		return bumpedFee, nil
+	}
+	if bumpedFee.DynamicFeeCap, err = enforce("fee cap", previousFee.DynamicFeeCap, bumpedFee.DynamicFeeCap, nil); err != nil {
+		return bumpedFee, err
+	}
+	return bumpedFee, nil
+}
+
+// minReplacementFee returns the lowest fee component that replaces previous: the min bump percentage over it, and at
+// least bumpMin more if set
+func (c *evmTxAttemptBuilder) minReplacementFee(previous, bumpMin *assets.Wei) *assets.Wei {
+	min := previous.AddPercentage(c.minBumpPercent())
+	if bumpMin != nil {
+		min = assets.WeiMax(min, previous.Add(bumpMin))
+	}
+	return min
+}
+
 // NewCustomTxAttempt is the lowest level func where the fee parameters + tx type must be passed in
 // used in the txm for force rebroadcast where fees and tx type are pre-determined without an estimator
 func (c *evmTxAttemptBuilder) NewCustomTxAttempt(etx Tx, fee gas.EvmFee, gasLimit uint32, txType int, lggr logger.Logger) (attempt TxAttempt, retryable bool, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewCustomTxAttempt", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	return c.newCustomTxAttempt(context.Background(), etx, fee, gasLimit, txType, nil, nil, lggr)
+}
+
+// NewTxAttemptFromEstimate builds an attempt from a fee and limit pair already returned by an estimator. Unlike
+// NewCustomTxAttempt, it first checks that the fee matches txType: a legacy tx must carry only a gas price and a dynamic
+// tx only a fee cap and tip cap. A mismatch is never retryable, since re-estimating would return the same pair.
+func (c *evmTxAttemptBuilder) NewTxAttemptFromEstimate(etx Tx, fee gas.EvmFee, limit uint32, txType int, lggr logger.Logger) (attempt TxAttempt, retryable bool, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewTxAttemptFromEstimate", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	if err = validateFeeForType(fee, txType); err != nil {
+		return attempt, false, pkgerrors.Wrapf(err, "cannot create tx attempt for tx %d", etx.ID) // not retryable
+	}
+	return c.newCustomTxAttempt(context.Background(), etx, fee, limit, txType, nil, nil, lggr)
+}
+
+// validateFeeForType checks that fee has exactly the components txType needs.
+func validateFeeForType(fee gas.EvmFee, txType int) error {
+	hasDynamic := fee.DynamicFeeCap != nil || fee.DynamicTipCap != nil
+	switch txType {
+	case 0x0:
+		if fee.Legacy == nil || hasDynamic {
+			return pkgerrors.Wrapf(ErrFeeTypeMismatch, "tx type 0x0 requires a gas price only, got %s", fee)
+		}
+	case 0x2:
+		if !fee.ValidDynamic() || fee.Legacy != nil {
+			return pkgerrors.Wrapf(ErrFeeTypeMismatch, "tx type 0x2 requires a fee cap and tip cap only, got %s", fee)
+		}
+	default:
+		return &ErrUnrecognizedTxType{txType: txType}
+	}
+	return nil
+}
+
+// NewCustomTxAttemptForChain is NewCustomTxAttempt for a tx destined for another chain, e.g. a sibling chain signed for
+// by the same relayer. The tx is built and signed with chainID, or with the builder's chain ID if chainID is nil.
+func (c *evmTxAttemptBuilder) NewCustomTxAttemptForChain(etx Tx, fee gas.EvmFee, gasLimit uint32, txType int, chainID *big.Int, lggr logger.Logger) (attempt TxAttempt, retryable bool, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewCustomTxAttemptForChain", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	if err = validateChainIDOverride(chainID); err != nil {
+		return attempt, false, err // not retryable
+	}
+	return c.newCustomTxAttempt(context.Background(), etx, fee, gasLimit, txType, chainID, nil, lggr)
+}
+
+// newCustomTxAttempt builds and signs the attempt for chainID, which defaults to the builder's chain ID if nil
+func (c *evmTxAttemptBuilder) newCustomTxAttempt(ctx context.Context, etx Tx, fee gas.EvmFee, gasLimit uint32, txType int, chainID *big.Int, accessList types.AccessList, lggr logger.Logger, opts ...feetypes.Opt) (attempt TxAttempt, retryable bool, err error) {
+	// reject before signing so an oversized payload never consumes a nonce
+	if err = c.validatePayloadSize(etx); err != nil {
+		return attempt, false, err // not retryable
+	}
+	// a zero gas limit can never be mined, so this is an estimator or caller bug rather than a transient failure
+	if gasLimit == 0 {
+		return attempt, false, pkgerrors.Wrapf(ErrZeroGasLimit, "cannot create tx attempt for tx %d", etx.ID) // not retryable
+	}
 	switch txType {
 	case 0x0: // legacy
-		if fee.Legacy == nil {
-			err = errors.Errorf("Attempt %v is a type 0 transaction but estimator did not return legacy fee bump", attempt.ID)
-			logger.Sugared(lggr).AssumptionViolation(err.Error())
+		if !fee.ValidLegacy() {
+			err = pkgerrors.Errorf("Attempt %v is a type 0 transaction but estimator did not return legacy fee bump", attempt.ID)
+			c.assumptionViolation(lggr, err)
+			return attempt, false, err // not retryable
+		}
+		if err = c.checkMaxTotalCost(etx, fee.Legacy, gasLimit); err != nil {
 			return attempt, false, err // not retryable
 		}
-		attempt, err = c.newLegacyAttempt(etx, fee.Legacy, gasLimit)
+		attempt, err = c.newLegacyAttempt(ctx, etx, fee.Legacy, gasLimit, chainID, opts...)
+		if err == nil {
+			attempt.FeeCapped = c.feeCapped(etx, attempt.TxFee.Legacy, lggr)
+		}
 		return attempt, true, err
 	case 0x2: // dynamic, EIP1559
+		if fee.DynamicTipCap != nil && fee.DynamicFeeCap == nil && c.feeConfig.TipOnlyDynamicFees() {
+			if fee.DynamicFeeCap, err = c.deriveFeeCap(etx, fee.DynamicTipCap, lggr); err != nil {
+				return attempt, true, err
+			}
+		}
 		if !fee.ValidDynamic() {
-			err = errors.Errorf("Attempt %v is a type 2 transaction but estimator did not return dynamic fee bump", attempt.ID)
-			logger.Sugared(lggr).AssumptionViolation(err.Error())
+			err = pkgerrors.Errorf("Attempt %v is a type 2 transaction but estimator did not return dynamic fee bump", attempt.ID)
+			c.assumptionViolation(lggr, err)
+			return attempt, false, err // not retryable
+		}
+		if err = c.checkMaxTotalCost(etx, fee.DynamicFeeCap, gasLimit); err != nil {
 			return attempt, false, err // not retryable
 		}
-		attempt, err = c.newDynamicFeeAttempt(etx, gas.DynamicFee{
+		attempt, err = c.newDynamicFeeAttempt(ctx, etx, gas.DynamicFee{
 			FeeCap: fee.DynamicFeeCap,
 			TipCap: fee.DynamicTipCap,
-		}, gasLimit)
+		}, gasLimit, chainID, accessList, opts...)
+		if err == nil {
+			attempt.FeeCapped = c.feeCapped(etx, attempt.TxFee.DynamicFeeCap, lggr)
+		}
 		return attempt, true, err
 	default:
-		err = errors.Errorf("invariant violation: Attempt %v had unrecognised transaction type %v"+
-			"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", attempt.ID, attempt.TxType)
-		logger.Sugared(lggr).AssumptionViolation(err.Error())
+		err = &ErrUnrecognizedTxType{attemptID: attempt.ID, txType: txType}
+		c.assumptionViolation(lggr, err)
 		return attempt, false, err // not retryable
 	}
 }
 
+// ErrUnrecognizedTxType is returned when building an attempt with a tx type the builder does not support
+type ErrUnrecognizedTxType struct {
+	attemptID int64
+	txType    int
+}
+
+func (e *ErrUnrecognizedTxType) Error() string {
+	return fmt.Sprintf("invariant violation: Attempt %v had unrecognised transaction type %v. "+
+		"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", e.attemptID, e.txType)
+}
+
+// TxType returns the unrecognised tx type
+func (e *ErrUnrecognizedTxType) TxType() int {
+	return e.txType
+}
+
+// DefaultTipOnlyBaseFeeMultiplier is the multiple of the latest base fee included in fee caps derived for
+// tip-only dynamic fees, leaving room for the base fee to rise before the tx is included
+const DefaultTipOnlyBaseFeeMultiplier uint16 = 2
+
+// tipOnlyBaseFeeMultiplier returns the base fee multiplier for derived fee caps, which can be overridden by
+// TipOnlyBaseFeeMultiplier
+func (c *evmTxAttemptBuilder) tipOnlyBaseFeeMultiplier() uint16 {
+	if multiplier := c.feeConfig.TipOnlyBaseFeeMultiplier(); multiplier > 0 {
+		return multiplier
+	}
+	return DefaultTipOnlyBaseFeeMultiplier
+}
+
+// deriveFeeCap computes baseFee*multiplier + tipCap from the latest base fee, capped at the max gas price for the key
+func (c *evmTxAttemptBuilder) deriveFeeCap(etx Tx, tipCap *assets.Wei, lggr logger.Logger) (*assets.Wei, error) {
+	if c.baseFeeSource == nil {
+		return nil, pkgerrors.Errorf("cannot derive fee cap for tip-only attempt for tx %v: no base fee source is configured", etx.ID)
+	}
+	baseFee, err := c.baseFeeSource.LatestBaseFee()
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "cannot derive fee cap for tip-only attempt for tx %v: failed to get latest base fee", etx.ID)
+	}
+	if baseFee == nil {
+		return nil, pkgerrors.Errorf("cannot derive fee cap for tip-only attempt for tx %v: latest head has no base fee", etx.ID)
+	}
+	multiplier := c.tipOnlyBaseFeeMultiplier()
+	feeCap := baseFee.Mul(big.NewInt(int64(multiplier))).Add(tipCap)
+	if max := c.feeConfig.PriceMaxKey(etx.FromAddress); feeCap.Cmp(max) > 0 {
+		feeCap = max
+	}
+	lggr.Debugw("Derived fee cap for tip-only attempt", "txID", etx.ID, "baseFee", baseFee, "multiplier", multiplier, "tipCap", tipCap, "feeCap", feeCap)
+	return feeCap, nil
+}
+
+// validatePayloadSize returns ErrPayloadTooLarge if the calldata exceeds MaxPayloadBytes, zero meaning unlimited
+func (c *evmTxAttemptBuilder) validatePayloadSize(etx Tx) error {
+	max := c.feeConfig.MaxPayloadBytes()
+	if max == 0 || len(etx.EncodedPayload) <= int(max) {
+		return nil
+	}
+	return pkgerrors.Wrapf(ErrPayloadTooLarge, "cannot create tx attempt: payload of %d bytes exceeds max of %d bytes", len(etx.EncodedPayload), max)
+}
+
+// feeCapped returns true if the fee has reached the max gas price configured for the sending key.
+// Estimators clamp to this max, so this indicates the estimator may have wanted to pay more than allowed.
+func (c *evmTxAttemptBuilder) feeCapped(etx Tx, fee *assets.Wei, lggr logger.Logger) bool {
+	max := c.feeConfig.PriceMaxKey(etx.FromAddress)
+	if fee.Cmp(max) < 0 {
+		return false
+	}
+	lggr.Debugw("Attempt fee capped by max gas price for key", "txID", etx.ID, "fee", fee, "priceMaxKey", max, "fromAddress", etx.FromAddress)
+	return true
+}
+
+// IsUnderpriced returns true if the attempt would be rejected as underpriced at the given base fee, i.e. its fee cap
+// (dynamic) or gas price (legacy) is below it. TxAttempt is a generic type from common/txmgr/types, so this cannot
+// be a method on it. Returns false if the base fee is unknown.
+func IsUnderpriced(a TxAttempt, baseFee *assets.Wei) bool {
+	if baseFee == nil {
+		return false
+	}
+	var price *assets.Wei
+	switch a.TxType {
+	case 0x0:
+		price = a.TxFee.Legacy
+	case 0x2:
+		price = a.TxFee.DynamicFeeCap
+	}
+	return price != nil && price.Cmp(baseFee) < 0
+}
+
+// IntrinsicGas returns the gas charged for the tx before any execution: the base tx cost, the calldata cost of
+// EncodedPayload (EIP-2028), and for typed txs the cost of any access list entries (EIP-2930). Txs never carry
+// an access list themselves, so callers that attach one must pass it in. Useful for sanity-checking etx.FeeLimit.
+func IntrinsicGas(etx Tx, txType int, accessList ...types.AccessTuple) (uint64, error) {
+	switch txType {
+	case 0x0:
+		if len(accessList) > 0 {
+			return 0, pkgerrors.New("access lists are not supported by legacy transactions")
+		}
+	case 0x1, 0x2:
+	default:
+		return 0, pkgerrors.Errorf("unrecognised transaction type %v", txType)
+	}
+	total := params.TxGas + gas.CalldataGas(etx.EncodedPayload)
+	total += uint64(len(accessList)) * params.TxAccessListAddressGas
+	total += uint64(types.AccessList(accessList).StorageKeys()) * params.TxAccessListStorageKeyGas
+	return total, nil
+}
+
 // NewEmptyTxAttempt is used in ForceRebroadcast to create a signed tx with zero value sent to the zero address
 func (c *evmTxAttemptBuilder) NewEmptyTxAttempt(nonce evmtypes.Nonce, feeLimit uint32, fee gas.EvmFee, fromAddress common.Address) (attempt TxAttempt, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewEmptyTxAttempt", 0x0, time.Since(start), err, false)
+	}(time.Now())
 	value := big.NewInt(0)
 	payload := []byte{}
 
-	if fee.Legacy == nil {
-		return attempt, errors.New("NewEmptyTranscation: legacy fee cannot be nil")
+	if !fee.ValidLegacy() {
+		return attempt, pkgerrors.New("NewEmptyTranscation: legacy fee cannot be nil")
 	}
 
 	tx := types.NewTransaction(uint64(nonce), fromAddress, value, uint64(feeLimit), fee.Legacy.ToInt(), payload)
 
-	hash, signedTxBytes, err := c.SignTx(fromAddress, tx)
+	hash, signedTxBytes, err := c.signTx(context.Background(), fromAddress, tx)
 	if err != nil {
-		return attempt, errors.Wrapf(err, "error using account %s to sign empty transaction", fromAddress.String())
+		return attempt, pkgerrors.Wrapf(err, "error using account %s to sign empty transaction", fromAddress.String())
 	}
 
 	attempt.SignedRawTx = signedTxBytes
@@ -132,23 +1291,160 @@ func (c *evmTxAttemptBuilder) NewEmptyTxAttempt(nonce evmtypes.Nonce, feeLimit u
 
 }
 
-func (c *evmTxAttemptBuilder) newDynamicFeeAttempt(etx Tx, fee gas.DynamicFee, gasLimit uint32) (attempt TxAttempt, err error) {
-	if err = validateDynamicFeeGas(c.feeConfig, c.feeConfig.TipCapMin(), fee, gasLimit, etx); err != nil {
-		return attempt, errors.Wrap(err, "error validating gas")
+// RecoverSender returns the address that signed the attempt, recovered from its signed raw tx for the builder's chain ID
+func (c *evmTxAttemptBuilder) RecoverSender(a TxAttempt) (common.Address, error) {
+	tx, err := GetGethSignedTx(a.SignedRawTx)
+	if err != nil {
+		return common.Address{}, pkgerrors.Wrapf(err, "failed to decode signed raw tx of attempt %d", a.ID)
+	}
+	return recoverSender(tx, &c.chainID)
+}
+
+// recoverSender returns the signer of tx for chainID
+func recoverSender(tx *types.Transaction, chainID *big.Int) (common.Address, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return common.Address{}, pkgerrors.Wrap(err, "failed to recover sender")
+	}
+	return from, nil
+}
+
+// NewAttemptFromSignedRawTx builds an attempt for etx from previously signed bytes without re-signing, e.g. to
+// rebroadcast the identical tx where signing is expensive. The fee, tx type and fee limit are taken from the decoded tx,
+// which must be signed by etx.FromAddress for this chain.
+func (c *evmTxAttemptBuilder) NewAttemptFromSignedRawTx(etx Tx, signedRawTx []byte) (attempt TxAttempt, err error) {
+	tx, err := GetGethSignedTx(signedRawTx)
+	if err != nil {
+		return attempt, pkgerrors.Wrap(err, "NewAttemptFromSignedRawTx: failed to decode signed raw tx")
+	}
+	from, err := recoverSender(tx, &c.chainID)
+	if err != nil {
+		return attempt, pkgerrors.Wrap(err, "NewAttemptFromSignedRawTx")
+	}
+	if from != etx.FromAddress {
+		return attempt, pkgerrors.Errorf("NewAttemptFromSignedRawTx: signed tx is from %s but tx %v is from %s", from, etx.ID, etx.FromAddress)
+	}
+	if etx.Sequence != nil && uint64(*etx.Sequence) != tx.Nonce() {
+		return attempt, pkgerrors.Errorf("NewAttemptFromSignedRawTx: signed tx has nonce %d but tx %v has sequence %d", tx.Nonce(), etx.ID, *etx.Sequence)
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		attempt.TxFee = gas.EvmFee{Legacy: assets.NewWei(tx.GasPrice())}
+	case types.DynamicFeeTxType:
+		attempt.TxFee = gas.EvmFee{DynamicFeeCap: assets.NewWei(tx.GasFeeCap()), DynamicTipCap: assets.NewWei(tx.GasTipCap())}
+	default:
+		return attempt, &ErrUnrecognizedTxType{txType: int(tx.Type())}
+	}
+	attempt.State = txmgrtypes.TxAttemptInProgress
+	attempt.SignedRawTx = signedRawTx
+	attempt.TxID = etx.ID
+	attempt.Tx = etx
+	attempt.Hash = tx.Hash()
+	attempt.TxType = int(tx.Type())
+	attempt.ChainSpecificFeeLimit = uint32(tx.Gas())
+	attempt.IdempotencyKey = idempotencyKeyOf(etx)
+	attempt.Meta = attemptMetaOf(etx)
+	attempt.Deadline = etx.Deadline
+	return attempt, nil
+}
+
+// NewReplacementTxAttempt builds a signed zero-value tx with the given nonce to an arbitrary destination with caller-supplied payload,
+// e.g. to replace a stuck tx with a non-empty no-op. The tx type is determined by the fee, and the same validation as other attempts applies,
+// including the payload size, gas limit and max total cost checks.
+func (c *evmTxAttemptBuilder) NewReplacementTxAttempt(nonce evmtypes.Nonce, feeLimit uint32, fee gas.EvmFee, fromAddress common.Address, toAddress common.Address, payload []byte) (attempt TxAttempt, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewReplacementTxAttempt", attempt.TxType, time.Since(start), err, false)
+	}(time.Now())
+	etx := Tx{
+		Sequence:       &nonce,
+		FromAddress:    fromAddress,
+		ToAddress:      toAddress,
+		EncodedPayload: payload,
+		Value:          *big.NewInt(0),
+	}
+	var txType int
+	switch {
+	case fee.ValidDynamic():
+		txType = 0x2
+	case fee.ValidLegacy():
+		txType = 0x0
+	default:
+		return attempt, pkgerrors.New("NewReplacementTxAttempt: fee must be either legacy or dynamic")
+	}
+	attempt, _, err = c.newCustomTxAttempt(context.Background(), etx, fee, feeLimit, txType, nil, nil, logger.NullLogger)
+	return attempt, pkgerrors.Wrap(err, "NewReplacementTxAttempt failed")
+}
+
+// NewCancelAttempt builds an attempt that cancels etx by replacing it with a zero value, empty payload tx from
+// etx.FromAddress to itself at the same sequence. bumpedFee must be high enough to replace the pending tx. The tx
+// type follows the fee config, and the same validation as other attempts applies.
+func (c *evmTxAttemptBuilder) NewCancelAttempt(etx Tx, bumpedFee gas.EvmFee, feeLimit uint32, lggr logger.Logger) (attempt TxAttempt, retryable bool, err error) {
+	txType := 0x0
+	if c.feeConfig.EIP1559DynamicFees() {
+		txType = 0x2
+	}
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("NewCancelAttempt", txType, time.Since(start), err, retryable)
+	}(time.Now())
+	etx.ToAddress = etx.FromAddress
+	etx.Value = *big.NewInt(0)
+	etx.EncodedPayload = []byte{}
+	return c.newCustomTxAttempt(context.Background(), etx, bumpedFee, feeLimit, txType, nil, nil, lggr)
+}
+
+// ConvertLegacyToDynamic re-expresses a legacy attempt as an EIP-1559 attempt, e.g. after a chain upgrade. The legacy
+// gas price becomes the fee cap, so the converted attempt never pays more than the original, and tip is the new tip
+// cap. The result is re-signed as type 0x2 with the same tx, sequence and gas limit, and keeps the attempt's ID. The
+// same validation as other attempts applies, so e.g. a payload over MaxPayloadBytes is rejected.
+func (c *evmTxAttemptBuilder) ConvertLegacyToDynamic(a TxAttempt, tip *assets.Wei) (attempt TxAttempt, err error) {
+	defer func(start time.Time) {
+		c.metrics.RecordAttemptBuild("ConvertLegacyToDynamic", attempt.TxType, time.Since(start), err, false)
+	}(time.Now())
+	if a.TxType != 0x0 || a.TxFee.Legacy == nil {
+		return attempt, pkgerrors.Errorf("cannot convert attempt %d to dynamic fees: expected a legacy attempt with a gas price, got tx type 0x%x", a.ID, a.TxType)
+	}
+	if tip == nil {
+		return attempt, pkgerrors.Errorf("cannot convert attempt %d to dynamic fees: tip cap is required", a.ID)
+	}
+	fee := gas.EvmFee{DynamicFeeCap: a.TxFee.Legacy, DynamicTipCap: tip}
+	attempt, _, err = c.newCustomTxAttempt(context.Background(), a.Tx, fee, a.ChainSpecificFeeLimit, 0x2, nil, nil, logger.NullLogger)
+	if err != nil {
+		return attempt, pkgerrors.Wrapf(err, "cannot convert attempt %d to dynamic fees", a.ID)
+	}
+	attempt.ID = a.ID
+	return attempt, nil
+}
+
+func (c *evmTxAttemptBuilder) newDynamicFeeAttempt(ctx context.Context, etx Tx, fee gas.DynamicFee, gasLimit uint32, chainID *big.Int, accessList types.AccessList, opts ...feetypes.Opt) (attempt TxAttempt, err error) {
+	if mbf := c.feeConfig.MandatoryBaseFee(); mbf != nil && fee.TipCap != nil && fee.FeeCap != nil {
+		if min := mbf.Add(fee.TipCap); fee.FeeCap.Cmp(min) < 0 {
+			fee.FeeCap = assets.WeiMin(min, c.feeConfig.PriceMaxKey(etx.FromAddress))
+		}
+	}
+	// trusted callers may skip validation, see OptSkipValidation
+	if !slices.Contains(opts, feetypes.OptSkipValidation) {
+		if err = validateDynamicFeeGas(c.feeConfig, c.feeConfig.TipCapMin(), c.feeConfig.MinFeeCapTipMarginWei(), fee, gasLimit, etx); err != nil {
+			return attempt, pkgerrors.Wrap(err, "error validating gas")
+		}
+	}
+	if etx.Sequence, err = c.sequenceFor(etx); err != nil {
+		return attempt, err
 	}
 
 	d := newDynamicFeeTransaction(
 		uint64(*etx.Sequence),
 		etx.ToAddress,
-		&etx.Value,
+		etx.ValueBigInt(),
 		gasLimit,
-		&c.chainID,
+		c.chainIDOrDefault(chainID),
 		fee.TipCap,
 		fee.FeeCap,
 		etx.EncodedPayload,
 	)
+	d.AccessList = accessList
 	tx := types.NewTx(&d)
-	attempt, err = c.newSignedAttempt(etx, tx)
+	attempt, err = c.newSignedAttempt(ctx, etx, tx, chainID)
 	if err != nil {
 		return attempt, err
 	}
@@ -161,6 +1457,23 @@ func (c *evmTxAttemptBuilder) newDynamicFeeAttempt(etx Tx, fee gas.DynamicFee, g
 	return attempt, nil
 }
 
+var (
+	// ErrFeeExceedsMax is returned when building an attempt with a fee above the max gas price configured for the key
+	ErrFeeExceedsMax = pkgerrors.New("fee exceeds max")
+	// ErrFeeBelowMin is returned when building an attempt with a fee below the configured minimum
+	ErrFeeBelowMin = pkgerrors.New("fee below min")
+	// ErrPayloadTooLarge is returned when building an attempt for a tx whose payload exceeds the chain's calldata limit
+	ErrPayloadTooLarge = pkgerrors.New("payload too large")
+	// ErrZeroGasLimit is returned when building an attempt with a gas limit of zero, which could never be mined
+	ErrZeroGasLimit = pkgerrors.New("gas limit must be greater than zero")
+	// ErrTotalCostExceedsMax is returned when the value plus the max gas cost of an attempt exceeds the configured max total cost
+	ErrTotalCostExceedsMax = pkgerrors.New("total cost exceeds max")
+	// ErrFeeTypeMismatch is returned when building an attempt with a fee whose components do not match the tx type
+	ErrFeeTypeMismatch = pkgerrors.New("fee does not match tx type")
+	// ErrEIP1559NotSupported is returned when EIP-1559 dynamic fees are enabled but the estimator cannot serve them
+	ErrEIP1559NotSupported = pkgerrors.New("estimator does not support EIP-1559")
+)
+
 var Max256BitUInt = big.NewInt(0).Exp(big.NewInt(2), big.NewInt(256), nil)
 
 type keySpecificEstimator interface {
@@ -169,7 +1482,7 @@ type keySpecificEstimator interface {
 
 // validateDynamicFeeGas is a sanity check - we have other checks elsewhere, but this
 // makes sure we _never_ create an invalid attempt
-func validateDynamicFeeGas(kse keySpecificEstimator, tipCapMinimum *assets.Wei, fee gas.DynamicFee, gasLimit uint32, etx Tx) error {
+func validateDynamicFeeGas(kse keySpecificEstimator, tipCapMinimum, feeCapTipMargin *assets.Wei, fee gas.DynamicFee, gasLimit uint32, etx Tx) error {
 	gasTipCap, gasFeeCap := fee.TipCap, fee.FeeCap
 
 	if gasTipCap == nil {
@@ -178,30 +1491,35 @@ func validateDynamicFeeGas(kse keySpecificEstimator, tipCapMinimum *assets.Wei,
 	if gasFeeCap == nil {
 		panic("gas fee cap missing")
 	}
+	var errs []error
 	// Assertions from:	https://github.com/ethereum/EIPs/blob/master/EIPS/eip-1559.md
 	// Prevent impossibly large numbers
 	if gasFeeCap.ToInt().Cmp(Max256BitUInt) > 0 {
-		return errors.New("impossibly large fee cap")
+		errs = append(errs, pkgerrors.New("impossibly large fee cap"))
 	}
 	if gasTipCap.ToInt().Cmp(Max256BitUInt) > 0 {
-		return errors.New("impossibly large tip cap")
+		errs = append(errs, pkgerrors.New("impossibly large tip cap"))
 	}
 	// The total must be at least as large as the tip
 	if gasFeeCap.Cmp(gasTipCap) < 0 {
-		return errors.Errorf("gas fee cap must be greater than or equal to gas tip cap (fee cap: %s, tip cap: %s)", gasFeeCap.String(), gasTipCap.String())
+		errs = append(errs, pkgerrors.Errorf("gas fee cap must be greater than or equal to gas tip cap (fee cap: %s, tip cap: %s)", gasFeeCap.String(), gasTipCap.String()))
+	} else if feeCapTipMargin != nil && gasFeeCap.Sub(gasTipCap).Cmp(feeCapTipMargin) < 0 {
+		// a tip eating (almost) the whole fee cap leaves no room for the base fee, so the tx would be stuck
+		errs = append(errs, pkgerrors.Errorf("gas fee cap must exceed gas tip cap by at least %s to leave room for the base fee (fee cap: %s, tip cap: %s)", feeCapTipMargin.String(), gasFeeCap.String(), gasTipCap.String()))
 	}
 
 	// Configuration sanity-check
 	max := kse.PriceMaxKey(etx.FromAddress)
 	if gasFeeCap.Cmp(max) > 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas fee cap of %s would exceed max configured gas price of %s for key %s", gasFeeCap.String(), max.String(), etx.FromAddress.String())
+		errs = append(errs, pkgerrors.Wrapf(ErrFeeExceedsMax, "cannot create tx attempt: specified gas fee cap of %s would exceed max configured gas price of %s for key %s", gasFeeCap.String(), max.String(), etx.FromAddress.String()))
 	}
 	// Tip must be above minimum
 	minTip := tipCapMinimum
 	if gasTipCap.Cmp(minTip) < 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas tip cap of %s is below min configured gas tip of %s for key %s", gasTipCap.String(), minTip.String(), etx.FromAddress.String())
+		errs = append(errs, pkgerrors.Wrapf(ErrFeeBelowMin, "cannot create tx attempt: specified gas tip cap of %s is below min configured gas tip of %s for key %s", gasTipCap.String(), minTip.String(), etx.FromAddress.String()))
 	}
-	return nil
+	// all failures are reported at once so a misconfigured key can be fixed in one go
+	return errors.Join(errs...)
 }
 
 func newDynamicFeeTransaction(nonce uint64, to common.Address, value *big.Int, gasLimit uint32, chainID *big.Int, gasTipCap, gasFeeCap *assets.Wei, data []byte) types.DynamicFeeTx {
@@ -217,24 +1535,33 @@ func newDynamicFeeTransaction(nonce uint64, to common.Address, value *big.Int, g
 	}
 }
 
-func (c *evmTxAttemptBuilder) newLegacyAttempt(etx Tx, gasPrice *assets.Wei, gasLimit uint32) (attempt TxAttempt, err error) {
-	if err = validateLegacyGas(c.feeConfig, c.feeConfig.PriceMin(), gasPrice, gasLimit, etx); err != nil {
-		return attempt, errors.Wrap(err, "error validating gas")
+func (c *evmTxAttemptBuilder) newLegacyAttempt(ctx context.Context, etx Tx, gasPrice *assets.Wei, gasLimit uint32, chainID *big.Int, opts ...feetypes.Opt) (attempt TxAttempt, err error) {
+	if mbf := c.feeConfig.MandatoryBaseFee(); mbf != nil && gasPrice != nil && gasPrice.Cmp(mbf) < 0 {
+		gasPrice = assets.WeiMin(mbf, c.feeConfig.PriceMaxKey(etx.FromAddress))
+	}
+	// trusted callers may skip validation, see OptSkipValidation
+	if !slices.Contains(opts, feetypes.OptSkipValidation) {
+		if err = validateLegacyGas(c.feeConfig, c.feeConfig.PriceMin(), gasPrice, gasLimit, etx); err != nil {
+			return attempt, pkgerrors.Wrap(err, "error validating gas")
+		}
+	}
+	if etx.Sequence, err = c.sequenceFor(etx); err != nil {
+		return attempt, err
 	}
 
 	tx := newLegacyTransaction(
 		uint64(*etx.Sequence),
 		etx.ToAddress,
-		&etx.Value,
+		etx.ValueBigInt(),
 		gasLimit,
 		gasPrice,
 		etx.EncodedPayload,
 	)
 
 	transaction := types.NewTx(&tx)
-	hash, signedTxBytes, err := c.SignTx(etx.FromAddress, transaction)
+	hash, signedTxBytes, err := c.signTxForChain(ctx, etx.FromAddress, transaction, chainID)
 	if err != nil {
-		return attempt, errors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress, etx.ID)
+		return attempt, pkgerrors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress, etx.ID)
 	}
 
 	attempt.State = txmgrtypes.TxAttemptInProgress
@@ -245,31 +1572,100 @@ func (c *evmTxAttemptBuilder) newLegacyAttempt(etx Tx, gasPrice *assets.Wei, gas
 	attempt.TxType = 0
 	attempt.ChainSpecificFeeLimit = gasLimit
 	attempt.Tx = etx
+	attempt.IdempotencyKey = idempotencyKeyOf(etx)
+	attempt.Meta = attemptMetaOf(etx)
+	attempt.Deadline = etx.Deadline
 
 	return attempt, nil
 }
 
+// ValidateAttempt re-runs the gas sanity checks against the stored fee of an already built attempt, using the
+// current fee config and the from address of the attempt's tx. Used to detect persisted attempts that are no longer
+// valid, e.g. after the max gas price for a key has been lowered.
+func (c *evmTxAttemptBuilder) ValidateAttempt(a TxAttempt) error {
+	switch a.TxType {
+	case 0x0:
+		if a.TxFee.Legacy == nil {
+			return pkgerrors.Errorf("attempt %d is missing a gas price", a.ID)
+		}
+		return validateLegacyGas(c.feeConfig, c.feeConfig.PriceMin(), a.TxFee.Legacy, a.ChainSpecificFeeLimit, a.Tx)
+	case 0x2:
+		if a.TxFee.DynamicTipCap == nil || a.TxFee.DynamicFeeCap == nil {
+			return pkgerrors.Errorf("attempt %d is missing a gas tip cap or fee cap", a.ID)
+		}
+		return validateDynamicFeeGas(c.feeConfig, c.feeConfig.TipCapMin(), c.feeConfig.MinFeeCapTipMarginWei(), gas.DynamicFee{TipCap: a.TxFee.DynamicTipCap, FeeCap: a.TxFee.DynamicFeeCap}, a.ChainSpecificFeeLimit, a.Tx)
+	default:
+		return &ErrUnrecognizedTxType{attemptID: a.ID, txType: a.TxType}
+	}
+}
+
 // validateLegacyGas is a sanity check - we have other checks elsewhere, but this
 // makes sure we _never_ create an invalid attempt
 func validateLegacyGas(kse keySpecificEstimator, minGasPriceWei, gasPrice *assets.Wei, gasLimit uint32, etx Tx) error {
 	if gasPrice == nil {
 		panic("gas price missing")
 	}
+	var errs []error
 	max := kse.PriceMaxKey(etx.FromAddress)
 	if gasPrice.Cmp(max) > 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas price of %s would exceed max configured gas price of %s for key %s", gasPrice.String(), max.String(), etx.FromAddress.String())
+		errs = append(errs, pkgerrors.Wrapf(ErrFeeExceedsMax, "cannot create tx attempt: specified gas price of %s would exceed max configured gas price of %s for key %s", gasPrice.String(), max.String(), etx.FromAddress.String()))
 	}
 	min := minGasPriceWei
 	if gasPrice.Cmp(min) < 0 {
-		return errors.Errorf("cannot create tx attempt: specified gas price of %s is below min configured gas price of %s for key %s", gasPrice.String(), min.String(), etx.FromAddress.String())
+		errs = append(errs, pkgerrors.Wrapf(ErrFeeBelowMin, "cannot create tx attempt: specified gas price of %s is below min configured gas price of %s for key %s", gasPrice.String(), min.String(), etx.FromAddress.String()))
+	}
+	return errors.Join(errs...)
+}
+
+// sequenceFor returns the sequence of the tx, falling back to the nonce provider if the tx does not have one
+func (c *evmTxAttemptBuilder) sequenceFor(etx Tx) (*evmtypes.Nonce, error) {
+	if etx.Sequence != nil {
+		return etx.Sequence, nil
+	}
+	if c.nonceProvider == nil {
+		return nil, pkgerrors.Wrapf(ErrMissingSequence, "cannot build attempt for tx %v", etx.ID)
+	}
+	nonce, err := c.nonceProvider.NextNonce(etx.FromAddress)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get next nonce for address %s", etx.FromAddress)
+	}
+	return &nonce, nil
+}
+
+// ErrDuplicateSequence is returned by CheckBatchSequences when two txs in a batch share a from address and sequence
+var ErrDuplicateSequence = pkgerrors.New("duplicate from address and sequence in batch")
+
+// CheckBatchSequences is a pre-flight check for building a batch of attempts. It returns an error naming the indices of
+// the first two txs that share a from address and sequence, since building both would sign conflicting attempts. Txs
+// without a sequence are skipped; their nonces are only assigned at build time.
+func CheckBatchSequences(etxs []Tx) error {
+	type key struct {
+		from     common.Address
+		sequence evmtypes.Nonce
+	}
+	seen := make(map[key]int, len(etxs))
+	for i, etx := range etxs {
+		if etx.Sequence == nil {
+			continue
+		}
+		k := key{etx.FromAddress, *etx.Sequence}
+		if j, ok := seen[k]; ok {
+			return pkgerrors.Wrapf(ErrDuplicateSequence, "txs at indices %d and %d both use sequence %d from %s", j, i, k.sequence, k.from)
+		}
+		seen[k] = i
 	}
 	return nil
 }
 
-func (c *evmTxAttemptBuilder) newSignedAttempt(etx Tx, tx *types.Transaction) (attempt TxAttempt, err error) {
-	hash, signedTxBytes, err := c.SignTx(etx.FromAddress, tx)
+func (c *evmTxAttemptBuilder) newSignedAttempt(ctx context.Context, etx Tx, tx *types.Transaction, chainID *big.Int) (attempt TxAttempt, err error) {
+	hash, signedTxBytes, err := c.signTxForChain(ctx, etx.FromAddress, tx, chainID)
 	if err != nil {
-		return attempt, errors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress.String(), etx.ID)
+		return attempt, pkgerrors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress.String(), etx.ID)
+	}
+	if c.verifySender {
+		if err = verifySender(signedTxBytes, c.chainIDOrDefault(chainID), etx.FromAddress); err != nil {
+			return attempt, pkgerrors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress.String(), etx.ID)
+		}
 	}
 
 	attempt.State = txmgrtypes.TxAttemptInProgress
@@ -277,10 +1673,45 @@ func (c *evmTxAttemptBuilder) newSignedAttempt(etx Tx, tx *types.Transaction) (a
 	attempt.TxID = etx.ID
 	attempt.Tx = etx
 	attempt.Hash = hash
+	attempt.IdempotencyKey = idempotencyKeyOf(etx)
+	attempt.Meta = attemptMetaOf(etx)
+	attempt.Deadline = etx.Deadline
 
 	return attempt, nil
 }
 
+func idempotencyKeyOf(etx Tx) string {
+	if etx.IdempotencyKey == nil {
+		return ""
+	}
+	return *etx.IdempotencyKey
+}
+
+// attemptMetaOf flattens the tx's meta into strings for the attempt: string values are used as is, and any other
+// non-null values as their JSON encoding. Returns nil if the tx has no meta or it is not a JSON object.
+func attemptMetaOf(etx Tx) map[string]string {
+	if etx.Meta == nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(*etx.Meta, &fields); err != nil || len(fields) == 0 {
+		return nil
+	}
+	meta := make(map[string]string, len(fields))
+	for k, raw := range fields {
+		var s string
+		switch {
+		case string(raw) == "null":
+			continue
+		case json.Unmarshal(raw, &s) == nil:
+			meta[k] = s
+		default:
+			meta[k] = string(raw)
+		}
+	}
+	return meta
+}
+
 func newLegacyTransaction(nonce uint64, to common.Address, value *big.Int, gasLimit uint32, gasPrice *assets.Wei, data []byte) types.LegacyTx {
 	return types.LegacyTx{
 		Nonce:    nonce,
@@ -293,20 +1724,122 @@ func newLegacyTransaction(nonce uint64, to common.Address, value *big.Int, gasLi
 }
 
 func (c *evmTxAttemptBuilder) SignTx(address common.Address, tx *types.Transaction) (common.Hash, []byte, error) {
-	signedTx, err := c.keystore.SignTx(address, tx, &c.chainID)
+	return c.signTx(context.Background(), address, tx)
+}
+
+// SignTxForChain signs tx like SignTx, but for chainID instead of the builder's chain ID. A nil chainID defaults to
+// the builder's chain ID.
+func (c *evmTxAttemptBuilder) SignTxForChain(address common.Address, tx *types.Transaction, chainID *big.Int) (common.Hash, []byte, error) {
+	if err := validateChainIDOverride(chainID); err != nil {
+		return common.Hash{}, nil, pkgerrors.Wrap(err, "SignTx failed")
+	}
+	return c.signTxForChain(context.Background(), address, tx, chainID)
+}
+
+// signTx signs with the configured signature scheme, passing ctx through so that remote signing can be cancelled
+func (c *evmTxAttemptBuilder) signTx(ctx context.Context, address common.Address, tx *types.Transaction) (common.Hash, []byte, error) {
+	return c.signTxForChain(ctx, address, tx, nil)
+}
+
+// signTxForChain is signTx for chainID, which defaults to the builder's chain ID if nil
+func (c *evmTxAttemptBuilder) signTxForChain(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (common.Hash, []byte, error) {
+	if c.buildDeterministic {
+		if d, ok := c.keystore.(DeterministicTxAttemptSigner); !ok || !d.Deterministic() {
+			return common.Hash{}, nil, pkgerrors.New("SignTx failed: deterministic builds require a deterministic signer")
+		}
+	}
+	if err := c.checkSignerBreaker(address); err != nil {
+		return common.Hash{}, nil, pkgerrors.Wrap(err, "SignTx failed")
+	}
+	txHash, signedRawTx, err := c.signatureScheme.Sign(ctx, c.keystore, address, tx, c.chainIDOrDefault(chainID))
+	c.recordSignerResult(address, err)
 	if err != nil {
-		return common.Hash{}, nil, errors.Wrap(err, "SignTx failed")
+		return common.Hash{}, nil, pkgerrors.Wrap(err, "SignTx failed")
 	}
-	rlp := new(bytes.Buffer)
-	if err := signedTx.EncodeRLP(rlp); err != nil {
-		return common.Hash{}, nil, errors.Wrap(err, "SignTx failed")
+	if c.strictEncoding {
+		if err = verifySignedRawTx(txHash, signedRawTx); err != nil {
+			return common.Hash{}, nil, pkgerrors.Wrap(err, "SignTx failed")
+		}
 	}
-	txHash := signedTx.Hash()
-	return txHash, rlp.Bytes(), nil
+	return txHash, signedRawTx, nil
 }
 
-func newEvmPriorAttempts(attempts []TxAttempt) (prior []gas.EvmPriorAttempt) {
+// chainIDOrDefault returns chainID, or the builder's chain ID if chainID is nil
+func (c *evmTxAttemptBuilder) chainIDOrDefault(chainID *big.Int) *big.Int {
+	if chainID == nil {
+		return &c.chainID
+	}
+	return chainID
+}
+
+// validateChainIDOverride checks that an explicit chain ID, if given, is usable for EIP-155 signing
+func validateChainIDOverride(chainID *big.Int) error {
+	if chainID != nil && chainID.Sign() == 0 {
+		return pkgerrors.New("chain ID must be non-zero")
+	}
+	return nil
+}
+
+// verifySender checks that signedRawTx was signed by from, e.g. to catch a keystore signing with the wrong key
+func verifySender(signedRawTx []byte, chainID *big.Int, from common.Address) error {
+	tx, err := GetGethSignedTx(signedRawTx)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to decode signed tx")
+	}
+	sender, err := recoverSender(tx, chainID)
+	if err != nil {
+		return err
+	}
+	if sender != from {
+		return pkgerrors.Wrapf(ErrSenderMismatch, "signed tx is from %s, expected %s", sender, from)
+	}
+	return nil
+}
+
+// verifySignedRawTx checks that signedRawTx decodes to a tx with the given hash
+func verifySignedRawTx(txHash common.Hash, signedRawTx []byte) error {
+	decoded, err := GetGethSignedTx(signedRawTx)
+	if err != nil {
+		return pkgerrors.Wrap(err, "signed tx failed RLP round-trip")
+	}
+	if decoded.Hash() != txHash {
+		return pkgerrors.Errorf("signed tx failed RLP round-trip: decoded hash %s does not match signed hash %s", decoded.Hash(), txHash)
+	}
+	return nil
+}
+
+// recentPriorAttempts returns the MaxPriorAttempts most recent attempts, i.e. those with the highest IDs, in their
+// original order. Zero means unlimited.
+// Estimators may rely on the full history, e.g. the block history estimator checks all prior attempts for
+// connectivity problems, so a low limit can delay detecting them.
+func (c *evmTxAttemptBuilder) recentPriorAttempts(attempts []TxAttempt) []TxAttempt {
+	max := int(c.feeConfig.MaxPriorAttempts())
+	if max == 0 || len(attempts) <= max {
+		return attempts
+	}
+	idxs := make([]int, len(attempts))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	slices.SortStableFunc(idxs, func(a, b int) int { return cmp.Compare(attempts[b].ID, attempts[a].ID) })
+	idxs = idxs[:max]
+	slices.Sort(idxs)
+	recent := make([]TxAttempt, len(idxs))
+	for i, idx := range idxs {
+		recent[i] = attempts[idx]
+	}
+	return recent
+}
+
+// newEvmPriorAttempts converts attempts for the estimator, skipping any without a usable legacy or dynamic fee
+// since they would confuse the bump logic
+func newEvmPriorAttempts(attempts []TxAttempt, lggr logger.Logger) (prior []gas.EvmPriorAttempt) {
+	var skipped int
 	for i := range attempts {
+		if attempts[i].TxFee.Legacy == nil && !attempts[i].TxFee.ValidDynamic() {
+			skipped++
+			continue
+		}
 		priorAttempt := gas.EvmPriorAttempt{
 			ChainSpecificFeeLimit:   attempts[i].ChainSpecificFeeLimit,
 			BroadcastBeforeBlockNum: attempts[i].BroadcastBeforeBlockNum,
@@ -320,5 +1853,8 @@ func newEvmPriorAttempts(attempts []TxAttempt) (prior []gas.EvmPriorAttempt) {
 		}
 		prior = append(prior, priorAttempt)
 	}
+	if skipped > 0 {
+		lggr.Debugw("Skipped prior attempts without fee data", "skipped", skipped, "total", len(attempts))
+	}
 	return
 }
@@ -1,21 +1,34 @@
 package txmgr_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 
+	commonfee "github.com/smartcontractkit/chainlink/v2/common/fee"
+	feetypes "github.com/smartcontractkit/chainlink/v2/common/fee/types"
+	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas"
 	gasmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/mocks"
+	rollupsmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/gas/rollups/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
@@ -24,6 +37,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	ksmocks "github.com/smartcontractkit/chainlink/v2/core/services/keystore/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg/datatypes"
 )
 
 func NewEvmAddress() gethcommon.Address {
@@ -31,24 +45,60 @@ func NewEvmAddress() gethcommon.Address {
 }
 
 type feeConfig struct {
-	eip1559DynamicFees bool
-	tipCapMin          *assets.Wei
-	priceMin           *assets.Wei
-	priceMax           *assets.Wei
+	eip1559DynamicFees       bool
+	bumpMin                  *assets.Wei
+	limitDefault             uint32
+	tipCapDefault            *assets.Wei
+	tipCapMin                *assets.Wei
+	priceMin                 *assets.Wei
+	priceMax                 *assets.Wei
+	minBumpPercent           uint16
+	tipOnlyDynamicFees       bool
+	tipOnlyBaseFeeMultiplier uint16
+	mandatoryBaseFee         *assets.Wei
+	maxPayloadBytes          uint32
+	upgradeLegacyOnBump      bool
+	bumpTipOnly              bool
+	maxPriorAttempts         uint32
+	minFeeCapTipMargin       *assets.Wei
+	bumpGasLimitPercent      uint16
+	bumpGasLimitMax          uint32
+	maxTotalCost             *assets.Wei
+	bumpJitterBasisPoints    uint16
+	stripAccessListOnBump    bool
 }
 
 func newFeeConfig() *feeConfig {
 	return &feeConfig{
-		tipCapMin: assets.NewWeiI(0),
-		priceMin:  assets.NewWeiI(0),
-		priceMax:  assets.NewWeiI(0),
+		bumpMin:       assets.NewWeiI(0),
+		tipCapDefault: assets.NewWeiI(0),
+		tipCapMin:     assets.NewWeiI(0),
+		priceMin:      assets.NewWeiI(0),
+		priceMax:      assets.NewWeiI(0),
 	}
 }
 
 func (g *feeConfig) EIP1559DynamicFees() bool                        { return g.eip1559DynamicFees }
+func (g *feeConfig) BumpMin() *assets.Wei                            { return g.bumpMin }
+func (g *feeConfig) LimitDefault() uint32                            { return g.limitDefault }
+func (g *feeConfig) TipCapDefault() *assets.Wei                      { return g.tipCapDefault }
 func (g *feeConfig) TipCapMin() *assets.Wei                          { return g.tipCapMin }
 func (g *feeConfig) PriceMin() *assets.Wei                           { return g.priceMin }
 func (g *feeConfig) PriceMaxKey(addr gethcommon.Address) *assets.Wei { return g.priceMax }
+func (g *feeConfig) MinBumpPercent() uint16                          { return g.minBumpPercent }
+func (g *feeConfig) TipOnlyDynamicFees() bool                        { return g.tipOnlyDynamicFees }
+func (g *feeConfig) TipOnlyBaseFeeMultiplier() uint16                { return g.tipOnlyBaseFeeMultiplier }
+func (g *feeConfig) MandatoryBaseFee() *assets.Wei                   { return g.mandatoryBaseFee }
+func (g *feeConfig) MaxPayloadBytes() uint32                         { return g.maxPayloadBytes }
+func (g *feeConfig) UpgradeLegacyOnBump() bool                       { return g.upgradeLegacyOnBump }
+func (g *feeConfig) BumpTipOnly() bool                               { return g.bumpTipOnly }
+func (g *feeConfig) MaxPriorAttempts() uint32                        { return g.maxPriorAttempts }
+func (g *feeConfig) MinFeeCapTipMarginWei() *assets.Wei              { return g.minFeeCapTipMargin }
+func (g *feeConfig) BumpGasLimitPercent() uint16                     { return g.bumpGasLimitPercent }
+func (g *feeConfig) BumpGasLimitMax() uint32                         { return g.bumpGasLimitMax }
+func (g *feeConfig) MaxTotalCostWei() *assets.Wei                    { return g.maxTotalCost }
+func (g *feeConfig) BumpJitterBasisPoints() uint16                   { return g.bumpJitterBasisPoints }
+func (g *feeConfig) StripAccessListOnBump() bool                     { return g.stripAccessListOnBump }
 
 func TestTxm_SignTx(t *testing.T) {
 	t.Parallel()
@@ -85,6 +135,192 @@ func TestTxm_SignTx(t *testing.T) {
 		require.NotNil(t, rawBytes)
 		require.Equal(t, "0xdd68f554373fdea7ec6713a6e437e7646465d553a6aa0b43233093366cc87ef0", hash.String())
 	})
+	t.Run("uses the standard EVM signature scheme by default", func(t *testing.T) {
+		chainID := big.NewInt(1)
+		kst := ksmocks.NewEth(t)
+		kst.On("SignTx", addr, tx, chainID).Return(tx, nil).Twice()
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), kst, nil)
+		hash, rawBytes, err := cks.SignTx(addr, tx)
+		require.NoError(t, err)
+
+		expectedHash, expectedBytes, err := txmgr.EvmSignatureScheme{}.Sign(testutils.Context(t), kst, addr, tx, chainID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedHash, hash)
+		assert.Equal(t, expectedBytes, rawBytes)
+	})
+	t.Run("uses the configured signature scheme", func(t *testing.T) {
+		chainID := big.NewInt(1)
+		kst := ksmocks.NewEth(t)
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), kst, nil)
+		cks.SetSignatureScheme(&stubSignatureScheme{})
+		hash, rawBytes, err := cks.SignTx(addr, tx)
+		require.NoError(t, err)
+		assert.Equal(t, gethcommon.HexToHash("0x01"), hash)
+		assert.Equal(t, []byte{0xff, 0x2a}, rawBytes)
+	})
+	t.Run("wraps signature scheme errors", func(t *testing.T) {
+		chainID := big.NewInt(1)
+		kst := ksmocks.NewEth(t)
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), kst, nil)
+		cks.SetSignatureScheme(&stubSignatureScheme{err: errors.New("boom")})
+		_, _, err := cks.SignTx(addr, tx)
+		require.EqualError(t, err, "SignTx failed: boom")
+	})
+}
+
+func TestTxm_HashFromSignedRawTx(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	to := NewEvmAddress()
+
+	for _, tt := range []struct {
+		name   string
+		txType int
+		txData gethtypes.TxData
+	}{
+		{"legacy", gethtypes.LegacyTxType, &gethtypes.LegacyTx{Nonce: 1, To: &to, Value: big.NewInt(2), Gas: 21000, GasPrice: big.NewInt(3)}},
+		{"dynamic", gethtypes.DynamicFeeTxType, &gethtypes.DynamicFeeTx{ChainID: chainID, Nonce: 1, To: &to, Value: big.NewInt(2), Gas: 21000, GasTipCap: big.NewInt(3), GasFeeCap: big.NewInt(4)}},
+		{"blob", gethtypes.BlobTxType, &gethtypes.BlobTx{ChainID: uint256.NewInt(1), Nonce: 1, To: &to, Value: uint256.NewInt(2), Gas: 21000, GasTipCap: uint256.NewInt(3), GasFeeCap: uint256.NewInt(4), BlobFeeCap: uint256.NewInt(5), BlobHashes: []gethcommon.Hash{{0x01}}}},
+	} {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			signedTx, err := gethtypes.SignNewTx(key, gethtypes.NewCancunSigner(chainID), test.txData)
+			require.NoError(t, err)
+			raw := new(bytes.Buffer)
+			require.NoError(t, signedTx.EncodeRLP(raw))
+
+			hash, txType, err := txmgr.HashFromSignedRawTx(raw.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, signedTx.Hash(), hash)
+			assert.Equal(t, test.txType, txType)
+		})
+	}
+
+	t.Run("malformed input", func(t *testing.T) {
+		_, _, err := txmgr.HashFromSignedRawTx([]byte{0xde, 0xad})
+		require.ErrorContains(t, err, "failed to decode signed raw tx")
+		_, _, err = txmgr.HashFromSignedRawTx(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewAttemptFromSignedRawTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := NewEvmAddress()
+	chainID := big.NewInt(1)
+	cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), ksmocks.NewEth(t), nil)
+	nonce := evmtypes.Nonce(7)
+	etx := txmgr.Tx{ID: 42, Sequence: &nonce, FromAddress: from}
+
+	sign := func(t *testing.T, txData gethtypes.TxData) []byte {
+		signedTx, err := gethtypes.SignNewTx(key, gethtypes.LatestSignerForChainID(chainID), txData)
+		require.NoError(t, err)
+		raw := new(bytes.Buffer)
+		require.NoError(t, signedTx.EncodeRLP(raw))
+		return raw.Bytes()
+	}
+
+	t.Run("legacy", func(t *testing.T) {
+		raw := sign(t, &gethtypes.LegacyTx{Nonce: 7, To: &to, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(25)})
+		a, err := cks.NewAttemptFromSignedRawTx(etx, raw)
+		require.NoError(t, err)
+		hash, _, err := txmgr.HashFromSignedRawTx(raw)
+		require.NoError(t, err)
+		assert.Equal(t, hash, a.Hash)
+		assert.Equal(t, raw, a.SignedRawTx)
+		assert.Equal(t, 0x0, a.TxType)
+		assert.Equal(t, uint32(21000), a.ChainSpecificFeeLimit)
+		assert.Equal(t, gas.EvmFee{Legacy: assets.NewWeiI(25)}, a.TxFee)
+		assert.Equal(t, int64(42), a.TxID)
+		assert.Equal(t, txmgrtypes.TxAttemptInProgress, a.State)
+	})
+	t.Run("dynamic", func(t *testing.T) {
+		raw := sign(t, &gethtypes.DynamicFeeTx{ChainID: chainID, Nonce: 7, To: &to, Value: big.NewInt(1), Gas: 50000, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(30)})
+		a, err := cks.NewAttemptFromSignedRawTx(etx, raw)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, uint32(50000), a.ChainSpecificFeeLimit)
+		assert.Equal(t, gas.EvmFee{DynamicFeeCap: assets.NewWeiI(30), DynamicTipCap: assets.NewWeiI(2)}, a.TxFee)
+	})
+	t.Run("rejects a tx from another address", func(t *testing.T) {
+		raw := sign(t, &gethtypes.LegacyTx{Nonce: 7, To: &to, Gas: 21000, GasPrice: big.NewInt(25)})
+		other := etx
+		other.FromAddress = NewEvmAddress()
+		_, err := cks.NewAttemptFromSignedRawTx(other, raw)
+		require.ErrorContains(t, err, fmt.Sprintf("signed tx is from %s but tx 42 is from %s", from, other.FromAddress))
+	})
+	t.Run("rejects a nonce mismatch", func(t *testing.T) {
+		raw := sign(t, &gethtypes.LegacyTx{Nonce: 8, To: &to, Gas: 21000, GasPrice: big.NewInt(25)})
+		_, err := cks.NewAttemptFromSignedRawTx(etx, raw)
+		require.ErrorContains(t, err, "signed tx has nonce 8 but tx 42 has sequence 7")
+	})
+	t.Run("rejects an unsupported tx type", func(t *testing.T) {
+		raw := sign(t, &gethtypes.AccessListTx{ChainID: chainID, Nonce: 7, To: &to, Gas: 21000, GasPrice: big.NewInt(25)})
+		_, err := cks.NewAttemptFromSignedRawTx(etx, raw)
+		var typeErr *txmgr.ErrUnrecognizedTxType
+		require.True(t, errors.As(err, &typeErr))
+		assert.Equal(t, 0x1, typeErr.TxType())
+	})
+	t.Run("rejects malformed bytes", func(t *testing.T) {
+		_, err := cks.NewAttemptFromSignedRawTx(etx, []byte{0xde, 0xad})
+		require.ErrorContains(t, err, "failed to decode signed raw tx")
+	})
+}
+
+func TestTxm_SignTx_StrictEncoding(t *testing.T) {
+	addr := NewEvmAddress()
+	to := NewEvmAddress()
+	tx := gethtypes.NewTx(&gethtypes.LegacyTx{Nonce: 42, To: &to, Value: big.NewInt(142), Gas: 242, GasPrice: big.NewInt(342)})
+	chainID := big.NewInt(1)
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, tx, chainID).Return(tx, nil)
+
+	t.Run("accepts a faithful encoding", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), kst, nil)
+		cks.SetStrictEncoding(true)
+		hash, _, err := cks.SignTx(addr, tx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), hash)
+	})
+	t.Run("rejects a tampered encoding", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), kst, nil)
+		cks.SetSignatureScheme(tamperedSignatureScheme{})
+		_, _, err := cks.SignTx(addr, tx)
+		require.NoError(t, err, "tampering is only detected in strict mode")
+
+		cks.SetStrictEncoding(true)
+		_, _, err = cks.SignTx(addr, tx)
+		require.ErrorContains(t, err, "SignTx failed: signed tx failed RLP round-trip: decoded hash")
+	})
+}
+
+// tamperedSignatureScheme returns the hash of the signed tx alongside the encoding of a different tx
+type tamperedSignatureScheme struct{}
+
+func (tamperedSignatureScheme) Sign(ctx context.Context, signer txmgr.TxAttemptSigner[gethcommon.Address], address gethcommon.Address, tx *types.Transaction, chainID *big.Int) (gethcommon.Hash, []byte, error) {
+	hash, _, err := txmgr.EvmSignatureScheme{}.Sign(ctx, signer, address, tx, chainID)
+	if err != nil {
+		return gethcommon.Hash{}, nil, err
+	}
+	tampered, err := types.NewTx(&types.LegacyTx{Nonce: tx.Nonce() + 1, To: tx.To(), Value: tx.Value(), Gas: tx.Gas(), GasPrice: tx.GasPrice()}).MarshalBinary()
+	return hash, tampered, err
+}
+
+// stubSignatureScheme produces a recognisable layout (0xff prefix followed by the nonce) instead of an RLP encoded tx
+type stubSignatureScheme struct {
+	err error
+}
+
+func (s *stubSignatureScheme) Sign(_ context.Context, _ txmgr.TxAttemptSigner[gethcommon.Address], _ gethcommon.Address, tx *types.Transaction, _ *big.Int) (gethcommon.Hash, []byte, error) {
+	if s.err != nil {
+		return gethcommon.Hash{}, nil, s.err
+	}
+	return gethcommon.HexToHash("0x01"), []byte{0xff, byte(tx.Nonce())}, nil
 }
 
 func TestTxm_NewDynamicFeeTx(t *testing.T) {
@@ -111,6 +347,35 @@ func TestTxm_NewDynamicFeeTx(t *testing.T) {
 		assert.Equal(t, assets.GWei(100).String(), a.TxFee.DynamicTipCap.String())
 		assert.NotNil(t, a.TxFee.DynamicFeeCap)
 		assert.Equal(t, assets.GWei(200).String(), a.TxFee.DynamicFeeCap.String())
+		assert.True(t, a.FeeCapped)
+	})
+
+	t.Run("reports all gas validation failures together", func(t *testing.T) {
+		feeCfg := newFeeConfig()
+		feeCfg.priceMax = assets.GWei(4)
+		feeCfg.tipCapMin = assets.GWei(6)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), feeCfg, kst, nil)
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{
+			DynamicTipCap: assets.GWei(5),
+			DynamicFeeCap: assets.GWei(5),
+		}, 100, 0x2, lggr)
+		require.EqualError(t, err, fmt.Sprintf("error validating gas: "+
+			"cannot create tx attempt: specified gas fee cap of 5 gwei would exceed max configured gas price of 4 gwei for key %[1]s: fee exceeds max\n"+
+			"cannot create tx attempt: specified gas tip cap of 5 gwei is below min configured gas tip of 6 gwei for key %[1]s: fee below min", addr))
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+		require.ErrorIs(t, err, txmgr.ErrFeeBelowMin)
+	})
+
+	t.Run("does not flag fee cap below key max as capped", func(t *testing.T) {
+		feeCfg := newFeeConfig()
+		feeCfg.priceMax = assets.GWei(200)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), feeCfg, kst, nil)
+		a, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{
+			DynamicTipCap: assets.GWei(100),
+			DynamicFeeCap: assets.GWei(199),
+		}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.False(t, a.FeeCapped)
 	})
 
 	t.Run("verifies gas tip and fees", func(t *testing.T) {
@@ -176,12 +441,271 @@ func TestTxm_NewLegacyAttempt(t *testing.T) {
 		assert.Equal(t, "25 wei", a.TxFee.Legacy.String())
 		assert.Nil(t, a.TxFee.DynamicTipCap)
 		assert.Nil(t, a.TxFee.DynamicFeeCap)
+		assert.False(t, a.FeeCapped)
+	})
+
+	t.Run("flags gas price equal to key max as capped", func(t *testing.T) {
+		var n evmtypes.Nonce
+		a, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(50)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.True(t, a.FeeCapped)
 	})
 
 	t.Run("verifies max gas price", func(t *testing.T) {
 		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(100)}, 100, 0x0, lggr)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), fmt.Sprintf("specified gas price of 100 wei would exceed max configured gas price of 50 wei for key %s", addr.String()))
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+		require.NotErrorIs(t, err, txmgr.ErrFeeBelowMin)
+	})
+
+	t.Run("verifies min gas price", func(t *testing.T) {
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(5)}, 100, 0x0, lggr)
+		require.ErrorContains(t, err, fmt.Sprintf("specified gas price of 5 wei is below min configured gas price of 10 wei for key %s", addr.String()))
+		require.ErrorIs(t, err, txmgr.ErrFeeBelowMin)
+		require.NotErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_SupportedTxTypes(t *testing.T) {
+	gc := newFeeConfig()
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, ksmocks.NewEth(t), nil)
+	assert.Equal(t, []int{0x0}, cks.SupportedTxTypes())
+
+	gc.eip1559DynamicFees = true
+	assert.Equal(t, []int{0x0, 0x2}, cks.SupportedTxTypes())
+}
+
+type stubSigner struct {
+	err error
+}
+
+func (s *stubSigner) SignTx(_ gethcommon.Address, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	return tx, s.err
+}
+
+func TestTxm_EvmTxAttemptBuilder_CheckSignerReady(t *testing.T) {
+	addr := NewEvmAddress()
+	ctx := testutils.Context(t)
+
+	t.Run("ready", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), &stubSigner{}, nil)
+		require.NoError(t, cks.CheckSignerReady(ctx, addr))
+		assert.Equal(t, map[string]error{fmt.Sprintf("EvmTxAttemptBuilder.Signer(%s)", addr): nil}, cks.SignerHealthReport(ctx, addr))
+	})
+	t.Run("not ready", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), &stubSigner{err: errors.New("key locked")}, nil)
+		err := cks.CheckSignerReady(ctx, addr)
+		require.EqualError(t, err, fmt.Sprintf("signer not ready for address %s: key locked", addr))
+
+		report := cks.SignerHealthReport(ctx, addr)
+		require.Len(t, report, 1)
+		assert.EqualError(t, report[fmt.Sprintf("EvmTxAttemptBuilder.Signer(%s)", addr)], err.Error())
+	})
+}
+
+// remoteSigner blocks signing until it is released or the context is done
+type remoteSigner struct {
+	release chan struct{}
+}
+
+func (s *remoteSigner) SignTx(gethcommon.Address, *types.Transaction, *big.Int) (*types.Transaction, error) {
+	return nil, errors.New("remote signer must be called with a context")
+}
+
+func (s *remoteSigner) SignTxContext(ctx context.Context, _ gethcommon.Address, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	select {
+	case <-s.release:
+		return tx, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestTxm_EvmTxAttemptBuilder_RemoteSigner(t *testing.T) {
+	addr := NewEvmAddress()
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+
+	t.Run("prefers SignTxContext", func(t *testing.T) {
+		signer := &remoteSigner{release: make(chan struct{})}
+		close(signer.release)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, signer, nil)
+		_, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.NewWeiI(25)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		require.NoError(t, cks.CheckSignerReady(testutils.Context(t), addr))
+	})
+	t.Run("cancels signing with the attempt build context", func(t *testing.T) {
+		signer := &remoteSigner{release: make(chan struct{})}
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, signer, est)
+
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 10*time.Millisecond)
+		defer cancel()
+		_, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("does not call the signer with a cancelled context", func(t *testing.T) {
+		signer := &remoteSigner{release: make(chan struct{})}
+		close(signer.release)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, signer, nil)
+		ctx, cancel := context.WithCancel(testutils.Context(t))
+		cancel()
+		require.ErrorIs(t, cks.CheckSignerReady(ctx, addr), context.Canceled)
+	})
+}
+
+type staticNonceProvider struct {
+	nonce evmtypes.Nonce
+	err   error
+}
+
+func (p *staticNonceProvider) NextNonce(gethcommon.Address) (evmtypes.Nonce, error) {
+	return p.nonce, p.err
+}
+
+func TestTxm_NewCustomTxAttempt_NilSequence(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+
+	t.Run("without a nonce provider", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{FromAddress: addr}, fee, 100, 0x0, lggr)
+		require.ErrorIs(t, err, txmgr.ErrMissingSequence)
+	})
+	t.Run("with a failing nonce provider", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		cks.SetNonceProvider(&staticNonceProvider{err: errors.New("boom")})
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{FromAddress: addr}, fee, 100, 0x0, lggr)
+		require.ErrorContains(t, err, "boom")
+	})
+	t.Run("with a nonce provider", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Nonce() == 7 }), big.NewInt(1)).
+			Return(types.NewTx(&types.LegacyTx{Nonce: 7}), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		cks.SetNonceProvider(&staticNonceProvider{nonce: 7})
+		a, _, err := cks.NewCustomTxAttempt(txmgr.Tx{FromAddress: addr}, fee, 100, 0x0, lggr)
+		require.NoError(t, err)
+		require.NotNil(t, a.Tx.Sequence)
+		assert.Equal(t, evmtypes.Nonce(7), *a.Tx.Sequence)
+	})
+}
+
+func TestTxm_NewCustomTxAttempt_Value(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(
+		func(_ gethcommon.Address, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+			return tx, nil
+		})
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+
+	large := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	for _, tt := range []struct {
+		name  string
+		value big.Int
+	}{
+		{"zero", big.Int{}},
+		{"near 2^255", *large},
+	} {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			etx := txmgr.Tx{Sequence: &n, FromAddress: addr, Value: test.value}
+			for _, txType := range []int{0x0, 0x2} {
+				a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(1), DynamicFeeCap: assets.GWei(1), DynamicTipCap: assets.GWei(1)}, 100, txType, lggr)
+				require.NoError(t, err)
+				tx, err := txmgr.GetGethSignedTx(a.SignedRawTx)
+				require.NoError(t, err)
+				assert.Equal(t, 0, test.value.Cmp(tx.Value()))
+			}
+		})
+	}
+
+	t.Run("ValueBigInt returns a copy", func(t *testing.T) {
+		etx := txmgr.Tx{Value: *big.NewInt(42)}
+		v := etx.ValueBigInt()
+		v.SetInt64(0)
+		assert.Equal(t, int64(42), etx.Value.Int64())
+		assert.Equal(t, int64(0), (&txmgr.Tx{}).ValueBigInt().Int64())
+	})
+}
+
+func TestTxm_IsUnderpriced(t *testing.T) {
+	legacy := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(100)}}
+	dynamic := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(1), DynamicFeeCap: assets.NewWeiI(100)}}
+
+	for _, a := range []txmgr.TxAttempt{legacy, dynamic} {
+		assert.False(t, txmgr.IsUnderpriced(a, assets.NewWeiI(99)))
+		assert.False(t, txmgr.IsUnderpriced(a, assets.NewWeiI(100)))
+		assert.True(t, txmgr.IsUnderpriced(a, assets.NewWeiI(101)))
+		assert.False(t, txmgr.IsUnderpriced(a, nil))
+	}
+	assert.False(t, txmgr.IsUnderpriced(txmgr.TxAttempt{TxType: 0x2}, assets.NewWeiI(101)))
+}
+
+func TestTxm_NewReplacementTxAttempt(t *testing.T) {
+	fromAddress := NewEvmAddress()
+	toAddress := NewEvmAddress()
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", fromAddress, mock.Anything, big.NewInt(1)).Return(
+		func(_ gethcommon.Address, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+			return tx, nil
+		})
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+	t.Run("legacy", func(t *testing.T) {
+		a, err := cks.NewReplacementTxAttempt(evmtypes.Nonce(5), 100, gas.EvmFee{Legacy: assets.GWei(10)}, fromAddress, toAddress, payload)
+		require.NoError(t, err)
+		assert.Equal(t, 0x0, a.TxType)
+
+		tx, err := txmgr.GetGethSignedTx(a.SignedRawTx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), tx.Nonce())
+		assert.Equal(t, toAddress, *tx.To())
+		assert.Equal(t, payload, tx.Data())
+		assert.Equal(t, int64(0), tx.Value().Int64())
+	})
+	t.Run("dynamic", func(t *testing.T) {
+		a, err := cks.NewReplacementTxAttempt(evmtypes.Nonce(6), 100, gas.EvmFee{DynamicFeeCap: assets.GWei(20), DynamicTipCap: assets.GWei(1)}, fromAddress, toAddress, payload)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+
+		tx, err := txmgr.GetGethSignedTx(a.SignedRawTx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), tx.Nonce())
+		assert.Equal(t, toAddress, *tx.To())
+		assert.Equal(t, payload, tx.Data())
+	})
+	t.Run("validates fee", func(t *testing.T) {
+		_, err := cks.NewReplacementTxAttempt(evmtypes.Nonce(5), 100, gas.EvmFee{Legacy: assets.GWei(101)}, fromAddress, toAddress, payload)
+		require.ErrorContains(t, err, "would exceed max configured gas price")
+
+		_, err = cks.NewReplacementTxAttempt(evmtypes.Nonce(5), 100, gas.EvmFee{}, fromAddress, toAddress, payload)
+		require.EqualError(t, err, "NewReplacementTxAttempt: fee must be either legacy or dynamic")
+	})
+	t.Run("applies the same checks as other attempts", func(t *testing.T) {
+		_, err := cks.NewReplacementTxAttempt(evmtypes.Nonce(5), 0, gas.EvmFee{Legacy: assets.GWei(10)}, fromAddress, toAddress, payload)
+		require.ErrorIs(t, err, txmgr.ErrZeroGasLimit)
+
+		gc.maxPayloadBytes = uint32(len(payload)) - 1
+		t.Cleanup(func() { gc.maxPayloadBytes = 0 })
+		_, err = cks.NewReplacementTxAttempt(evmtypes.Nonce(5), 100, gas.EvmFee{Legacy: assets.GWei(10)}, fromAddress, toAddress, payload)
+		require.ErrorIs(t, err, txmgr.ErrPayloadTooLarge)
 	})
 }
 
@@ -213,13 +737,49 @@ func TestTxm_NewCustomTxAttempt_NonRetryableErrors(t *testing.T) {
 		_, retryable, err := cks.NewCustomTxAttempt(txmgr.Tx{}, gas.EvmFee{}, 100, 0xA, lggr)
 		require.Error(t, err)
 		assert.False(t, retryable)
+
+		var typeErr *txmgr.ErrUnrecognizedTxType
+		require.True(t, errors.As(err, &typeErr))
+		assert.Equal(t, 0xA, typeErr.TxType())
+		assert.Contains(t, err.Error(), "unrecognised transaction type 10")
 	})
 }
 
+func TestTxm_EvmTxAttemptBuilder_AssumptionViolationFatal(t *testing.T) {
+	kst := ksmocks.NewEth(t)
+
+	for _, tc := range []struct {
+		name   string
+		fatal  bool
+		tagged bool
+	}{
+		{"fatal by default", true, true},
+		{"downgraded to a plain error", false, false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			lggr, observed := logger.TestLoggerObserved(t, zapcore.DebugLevel)
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, nil)
+			if !tc.fatal {
+				cks.SetAssumptionViolationFatal(false)
+			}
+
+			_, retryable, err := cks.NewCustomTxAttempt(txmgr.Tx{}, gas.EvmFee{}, 100, 0xA, lggr)
+			require.Error(t, err)
+			assert.False(t, retryable)
+
+			logs := observed.FilterLevelExact(zapcore.ErrorLevel).All()
+			require.Len(t, logs, 1)
+			assert.Equal(t, tc.tagged, strings.Contains(logs[0].Message, "AssumptionViolation"), logs[0].Message)
+		})
+	}
+}
+
 func TestTxm_EvmTxAttemptBuilder_RetryableEstimatorError(t *testing.T) {
 	est := gasmocks.NewEvmFeeEstimator(t)
-	est.On("GetFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail"))
+	est.On("GetFeeForType", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail"))
 	est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail"))
+	est.On("SupportsEIP1559").Return(true)
 
 	kst := ksmocks.NewEth(t)
 	lggr := logger.TestLogger(t)
@@ -245,3 +805,2084 @@ func TestTxm_EvmTxAttemptBuilder_RetryableEstimatorError(t *testing.T) {
 		assert.True(t, retryable)
 	})
 }
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_MinBump(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+
+	newBuilder := func(t *testing.T, priceMax *assets.Wei, bumped gas.EvmFee) txmgr.TxAttemptBuilder {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(bumped, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.priceMax = priceMax
+		return txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	}
+	legacyAttempt := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(100)}}
+
+	t.Run("legacy bump at the threshold is unchanged", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1000), gas.EvmFee{Legacy: assets.NewWeiI(110)})
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, legacyAttempt, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(110), fee.Legacy)
+		assert.Equal(t, assets.NewWeiI(110), a.TxFee.Legacy)
+	})
+	t.Run("legacy bump below the threshold is raised to it", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1000), gas.EvmFee{Legacy: assets.NewWeiI(109)})
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, legacyAttempt, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(110), fee.Legacy)
+		assert.Equal(t, assets.NewWeiI(110), a.TxFee.Legacy)
+	})
+	t.Run("errors if the threshold exceeds the key max", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(109), gas.EvmFee{Legacy: assets.NewWeiI(109)})
+		_, _, _, retryable, err := cks.NewBumpTxAttempt(ctx, etx, legacyAttempt, nil, lggr)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+		assert.True(t, retryable)
+	})
+	t.Run("dynamic bump raises tip and cap independently", func(t *testing.T) {
+		previous := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(10), DynamicFeeCap: assets.NewWeiI(100)}}
+		cks := newBuilder(t, assets.NewWeiI(1000), gas.EvmFee{DynamicTipCap: assets.NewWeiI(10), DynamicFeeCap: assets.NewWeiI(110)})
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(11), fee.DynamicTipCap)
+		assert.Equal(t, assets.NewWeiI(110), fee.DynamicFeeCap)
+	})
+	t.Run("uses the configured min bump percent", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(110)}, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.priceMax = assets.NewWeiI(1000)
+		gc.minBumpPercent = 20
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, legacyAttempt, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(120), fee.Legacy)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_BumpMin(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	// at low prices the percentage bump is only a handful of wei
+	previous := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(20)}}
+
+	newBuilder := func(t *testing.T, bumpMin, priceMax *assets.Wei, bumped gas.EvmFee) txmgr.TxAttemptBuilder {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(bumped, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.bumpMin = bumpMin
+		gc.priceMax = priceMax
+		return txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	}
+
+	t.Run("raises a low price bump to the absolute floor", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1000), assets.GWei(1), gas.EvmFee{Legacy: assets.NewWeiI(22)})
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(1020), fee.Legacy)
+	})
+	t.Run("percentage floor applies when above the absolute floor", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1), assets.GWei(1), gas.EvmFee{Legacy: assets.NewWeiI(21)})
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(22), fee.Legacy)
+	})
+	t.Run("bumps above both floors are unchanged", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1000), assets.GWei(1), gas.EvmFee{Legacy: assets.NewWeiI(5000)})
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(5000), fee.Legacy)
+	})
+	t.Run("errors if the absolute floor exceeds the key max", func(t *testing.T) {
+		cks := newBuilder(t, assets.NewWeiI(1000), assets.NewWeiI(500), gas.EvmFee{Legacy: assets.NewWeiI(22)})
+		_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+	})
+	t.Run("does not apply to dynamic fees", func(t *testing.T) {
+		previous := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(20), DynamicFeeCap: assets.NewWeiI(100)}}
+		cks := newBuilder(t, assets.NewWeiI(1000), assets.GWei(1), gas.EvmFee{DynamicTipCap: assets.NewWeiI(22), DynamicFeeCap: assets.NewWeiI(110)})
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.NewWeiI(22), fee.DynamicTipCap)
+		assert.Equal(t, assets.NewWeiI(110), fee.DynamicFeeCap)
+	})
+}
+
+type staticBaseFeeSource struct {
+	baseFee *assets.Wei
+	err     error
+}
+
+func (s *staticBaseFeeSource) LatestBaseFee() (*assets.Wei, error) { return s.baseFee, s.err }
+
+func TestTxm_EvmTxAttemptBuilder_TipOnlyDynamicFees(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	tipOnly := gas.EvmFee{DynamicTipCap: assets.GWei(1)}
+
+	newConfig := func(multiplier uint16) *feeConfig {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		gc.tipOnlyDynamicFees = true
+		gc.tipOnlyBaseFeeMultiplier = multiplier
+		return gc
+	}
+
+	t.Run("errors on a missing fee cap if not enabled", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		cks.SetBaseFeeSource(&staticBaseFeeSource{baseFee: assets.GWei(10)})
+		_, retryable, err := cks.NewCustomTxAttempt(etx, tipOnly, 100, 0x2, lggr)
+		require.ErrorContains(t, err, "did not return dynamic fee bump")
+		assert.False(t, retryable)
+	})
+	t.Run("derives the fee cap from the base fee", func(t *testing.T) {
+		for _, tt := range []struct {
+			name       string
+			multiplier uint16
+			baseFee    *assets.Wei
+			expected   *assets.Wei
+		}{
+			{"default multiplier", 0, assets.GWei(10), assets.GWei(21)},
+			{"custom multiplier", 3, assets.GWei(10), assets.GWei(31)},
+			{"zero base fee", 2, assets.GWei(0), assets.GWei(1)},
+			{"capped at key max", 2, assets.GWei(60), assets.GWei(100)},
+		} {
+			test := tt
+			t.Run(test.name, func(t *testing.T) {
+				cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newConfig(test.multiplier), kst, nil)
+				cks.SetBaseFeeSource(&staticBaseFeeSource{baseFee: test.baseFee})
+				a, _, err := cks.NewCustomTxAttempt(etx, tipOnly, 100, 0x2, lggr)
+				require.NoError(t, err)
+				assert.Equal(t, 0x2, a.TxType)
+				assert.Equal(t, assets.GWei(1), a.TxFee.DynamicTipCap)
+				assert.Equal(t, test.expected, a.TxFee.DynamicFeeCap)
+			})
+		}
+	})
+	t.Run("does not override a fee cap that is set", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newConfig(2), kst, nil)
+		cks.SetBaseFeeSource(&staticBaseFeeSource{baseFee: assets.GWei(10)})
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicTipCap: assets.GWei(1), DynamicFeeCap: assets.GWei(5)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(5), a.TxFee.DynamicFeeCap)
+	})
+	t.Run("errors without a base fee", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newConfig(2), kst, nil)
+		_, retryable, err := cks.NewCustomTxAttempt(etx, tipOnly, 100, 0x2, lggr)
+		require.ErrorContains(t, err, "no base fee source is configured")
+		assert.True(t, retryable)
+
+		cks.SetBaseFeeSource(&staticBaseFeeSource{err: errors.New("rpc down")})
+		_, retryable, err = cks.NewCustomTxAttempt(etx, tipOnly, 100, 0x2, lggr)
+		require.ErrorContains(t, err, "failed to get latest base fee: rpc down")
+		assert.True(t, retryable)
+
+		cks.SetBaseFeeSource(&staticBaseFeeSource{})
+		_, _, err = cks.NewCustomTxAttempt(etx, tipOnly, 100, 0x2, lggr)
+		require.ErrorContains(t, err, "latest head has no base fee")
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_MinFeeCapTipMargin(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	gc.minFeeCapTipMargin = assets.GWei(1)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+	t.Run("accepts a fee cap exactly the margin above the tip cap", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		_, _, err := cks.NewCustomTxAttempt(etx, gas.NewDynamicFee(assets.GWei(3), assets.GWei(2)), 100, 0x2, lggr)
+		require.NoError(t, err)
+	})
+	t.Run("rejects a fee cap less than the margin above the tip cap", func(t *testing.T) {
+		_, _, err := cks.NewCustomTxAttempt(etx, gas.NewDynamicFee(assets.GWei(3).Sub(assets.NewWeiI(1)), assets.GWei(2)), 100, 0x2, lggr)
+		require.ErrorContains(t, err, "gas fee cap must exceed gas tip cap by at least 1 gwei to leave room for the base fee")
+	})
+	t.Run("does not apply to legacy attempts", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		_, _, err := cks.NewCustomTxAttempt(etx, gas.NewLegacyFee(assets.GWei(2)), 100, 0x0, lggr)
+		require.NoError(t, err)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_MandatoryBaseFee(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	gc.mandatoryBaseFee = assets.GWei(10)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+	t.Run("raises a legacy gas price to the mandatory base fee", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(5)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(10), a.TxFee.Legacy)
+	})
+	t.Run("leaves a legacy gas price above the mandatory base fee", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(20)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(20), a.TxFee.Legacy)
+	})
+	t.Run("raises a dynamic fee cap to the mandatory base fee plus tip", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicTipCap: assets.GWei(2), DynamicFeeCap: assets.GWei(5)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(2), a.TxFee.DynamicTipCap)
+		assert.Equal(t, assets.GWei(12), a.TxFee.DynamicFeeCap)
+	})
+	t.Run("leaves a dynamic fee cap above the floor", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicTipCap: assets.GWei(2), DynamicFeeCap: assets.GWei(50)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(50), a.TxFee.DynamicFeeCap)
+	})
+	t.Run("caps the floor at the key max", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(8)
+		gc.mandatoryBaseFee = assets.GWei(10)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(5)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(8), a.TxFee.Legacy)
+		assert.True(t, a.FeeCapped)
+
+		a, _, err = cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicTipCap: assets.GWei(2), DynamicFeeCap: assets.GWei(5)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(8), a.TxFee.DynamicFeeCap)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_SkipsFeelessPriorAttempts(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(1)
+
+	hash1, hash2, hash3 := testutils.NewHash(), testutils.NewHash(), testutils.NewHash()
+	priorAttempts := []txmgr.TxAttempt{
+		{Hash: hash1, TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(10)}},
+		{Hash: testutils.NewHash(), TxType: 0x0},
+		{Hash: hash2, TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(1), DynamicFeeCap: assets.NewWeiI(20)}},
+		{Hash: testutils.NewHash(), TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(1)}},
+		{Hash: hash3, TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(30)}},
+	}
+
+	var received []gas.EvmPriorAttempt
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { received = args.Get(4).([]gas.EvmPriorAttempt) }).
+		Return(gas.EvmFee{Legacy: assets.NewWeiI(40)}, uint32(100), nil).Once()
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+	_, _, _, _, err := cks.NewBumpTxAttempt(testutils.Context(t), etx, priorAttempts[4], priorAttempts, lggr)
+	require.NoError(t, err)
+	require.Len(t, received, 3)
+	assert.Equal(t, hash1, received[0].TxHash)
+	assert.Equal(t, hash2, received[1].TxHash)
+	assert.Equal(t, assets.NewWeiI(20), received[1].DynamicFee.FeeCap)
+	assert.Equal(t, hash3, received[2].TxHash)
+}
+
+func TestTxm_EvmTxAttemptBuilder_FeeEstimationTimeout(t *testing.T) {
+	// slowEstimate blocks until the estimator's context is done, or well past the timeout
+	slowEstimate := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(testutils.WaitTimeout(t)):
+			return nil
+		}
+	}
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("GetFeeForType", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, _ int, _ gethcommon.Address, _ []byte, _ uint32, _ *assets.Wei, _ ...feetypes.Opt) (gas.EvmFee, uint32, error) {
+			return gas.EvmFee{}, 0, slowEstimate(ctx)
+		})
+	est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, _ gas.EvmFee, _ uint32, _ *assets.Wei, _ []gas.EvmPriorAttempt, _ ...feetypes.Opt) (gas.EvmFee, uint32, error) {
+			return gas.EvmFee{}, 0, slowEstimate(ctx)
+		})
+
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+	cks.SetFeeEstimationTimeout(10 * time.Millisecond)
+
+	t.Run("NewTxAttemptWithType", func(t *testing.T) {
+		_, _, _, retryable, err := cks.NewTxAttemptWithType(ctx, txmgr.Tx{}, lggr, 0x0)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "failed to get fee: fee estimation timed out after 10ms")
+		assert.True(t, retryable)
+	})
+	t.Run("NewBumpTxAttempt", func(t *testing.T) {
+		_, _, _, retryable, err := cks.NewBumpTxAttempt(ctx, txmgr.Tx{}, txmgr.TxAttempt{}, nil, lggr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "failed to bump fee: fee estimation timed out after 10ms")
+		assert.True(t, retryable)
+	})
+}
+
+type attemptBuild struct {
+	method    string
+	txType    int
+	failed    bool
+	retryable bool
+}
+
+type recordingAttemptBuilderMetrics struct {
+	builds []attemptBuild
+}
+
+func (m *recordingAttemptBuilderMetrics) RecordAttemptBuild(method string, txType int, _ time.Duration, err error, retryable bool) {
+	m.builds = append(m.builds, attemptBuild{method: method, txType: txType, failed: err != nil, retryable: retryable})
+}
+
+func TestTxm_EvmTxAttemptBuilder_Metrics(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("GetFeeForType", mock.Anything, 0x2, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail")).Once()
+	est.On("SupportsEIP1559").Return(true).Once()
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.eip1559DynamicFees = true
+	gc.priceMax = assets.NewWeiI(50)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	metrics := &recordingAttemptBuilderMetrics{}
+	cks.SetMetrics(metrics)
+	var n evmtypes.Nonce
+
+	_, _, _, _, err := cks.NewTxAttempt(testutils.Context(t), txmgr.Tx{}, lggr)
+	require.Error(t, err)
+	_, _, err = cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(25)}, 100, 0x0, lggr)
+	require.NoError(t, err)
+	_, _, err = cks.NewCustomTxAttempt(txmgr.Tx{}, gas.EvmFee{}, 100, 0x0, lggr)
+	require.Error(t, err)
+
+	// nested calls to other builder methods are not double counted
+	assert.Equal(t, []attemptBuild{
+		{method: "NewTxAttempt", txType: 0x2, failed: true, retryable: true},
+		{method: "NewCustomTxAttempt", txType: 0x0, retryable: true},
+		{method: "NewCustomTxAttempt", txType: 0x0, failed: true, retryable: false},
+	}, metrics.builds)
+}
+
+func TestTxm_EvmTxAttemptBuilder_BuildWithIdempotencyKey(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	est := gasmocks.NewEvmFeeEstimator(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	var n evmtypes.Nonce
+	key := "job-1"
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, IdempotencyKey: &key}
+
+	t.Run("requires a key", func(t *testing.T) {
+		_, _, _, retryable, err := cks.BuildWithIdempotencyKey(ctx, txmgr.Tx{}, lggr)
+		require.ErrorContains(t, err, "tx has no idempotency key")
+		assert.False(t, retryable)
+	})
+	t.Run("releases the key if the build fails", func(t *testing.T) {
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail")).Once()
+		_, _, _, _, err := cks.BuildWithIdempotencyKey(ctx, etx, lggr)
+		require.EqualError(t, err, "failed to get fee: fail")
+	})
+	t.Run("builds the first attempt for a key", func(t *testing.T) {
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Once()
+		a, _, _, _, err := cks.BuildWithIdempotencyKey(ctx, etx, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, key, a.IdempotencyKey)
+	})
+	t.Run("rejects a second attempt for the same key", func(t *testing.T) {
+		_, _, _, retryable, err := cks.BuildWithIdempotencyKey(ctx, etx, lggr)
+		require.ErrorIs(t, err, txmgr.ErrDuplicateIdempotencyKey)
+		assert.False(t, retryable)
+	})
+	t.Run("remembers the key for the builder's lifetime by default", func(t *testing.T) {
+		now := time.Now()
+		cks.SetClock(func() time.Time { return now })
+		t.Cleanup(func() { cks.SetClock(time.Now) })
+		now = now.Add(365 * 24 * time.Hour)
+
+		_, _, _, _, err := cks.BuildWithIdempotencyKey(ctx, etx, lggr)
+		require.ErrorIs(t, err, txmgr.ErrDuplicateIdempotencyKey)
+	})
+	t.Run("forgets the key after the retention window", func(t *testing.T) {
+		now := time.Now()
+		cks.SetClock(func() time.Time { return now })
+		t.Cleanup(func() { cks.SetClock(time.Now) })
+		cks.SetIdempotencyKeyRetention(time.Hour)
+		t.Cleanup(func() { cks.SetIdempotencyKeyRetention(txmgr.NoIdempotencyKeyExpiry) })
+		other := "job-2"
+		otherTx := txmgr.Tx{Sequence: &n, FromAddress: addr, IdempotencyKey: &other}
+
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Once()
+		_, _, _, _, err := cks.BuildWithIdempotencyKey(ctx, otherTx, lggr)
+		require.NoError(t, err)
+
+		now = now.Add(time.Hour - time.Second)
+		_, _, _, _, err = cks.BuildWithIdempotencyKey(ctx, otherTx, lggr)
+		require.ErrorIs(t, err, txmgr.ErrDuplicateIdempotencyKey)
+
+		now = now.Add(time.Second)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Once()
+		_, _, _, _, err = cks.BuildWithIdempotencyKey(ctx, otherTx, lggr)
+		require.NoError(t, err)
+		_, _, _, _, err = cks.BuildWithIdempotencyKey(ctx, otherTx, lggr)
+		require.ErrorIs(t, err, txmgr.ErrDuplicateIdempotencyKey)
+	})
+	t.Run("zero retention disables duplicate detection", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.SetIdempotencyKeyRetention(0)
+
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Twice()
+		for i := 0; i < 2; i++ {
+			_, _, _, _, err := cks.BuildWithIdempotencyKey(ctx, etx, lggr)
+			require.NoError(t, err)
+		}
+	})
+	t.Run("other builder methods propagate the key without tracking it", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicFeeCap: assets.NewWeiI(25), DynamicTipCap: assets.NewWeiI(25)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, key, a.IdempotencyKey)
+
+		a, _, err = cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(25)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.Empty(t, a.IdempotencyKey)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_FeeCache(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, EncodedPayload: []byte{1, 2, 3}, FeeLimit: 100}
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+
+	newEstimator := func(t *testing.T, calls int) *gasmocks.EvmFeeEstimator {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fee, uint32(100), nil).Times(calls)
+		return est
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, newEstimator(t, 2))
+		for i := 0; i < 2; i++ {
+			_, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+			require.NoError(t, err)
+		}
+	})
+	t.Run("a cache hit does not call the estimator", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, newEstimator(t, 1))
+		cks.SetFeeCacheTTL(time.Minute)
+		for i := 0; i < 2; i++ {
+			_, f, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+			require.NoError(t, err)
+			assert.Equal(t, fee, f)
+		}
+	})
+	t.Run("different calldata is a cache miss", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, newEstimator(t, 2))
+		cks.SetFeeCacheTTL(time.Minute)
+		_, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+		other := etx
+		other.EncodedPayload = []byte{4, 5, 6}
+		_, _, _, _, err = cks.NewTxAttempt(ctx, other, lggr)
+		require.NoError(t, err)
+	})
+	t.Run("force refetch bypasses the cache", func(t *testing.T) {
+		est := newEstimator(t, 1)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything, feetypes.OptForceRefetch).Return(fee, uint32(100), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.SetFeeCacheTTL(time.Minute)
+		_, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+		_, _, _, _, err = cks.NewTxAttempt(ctx, etx, lggr, feetypes.OptForceRefetch)
+		require.NoError(t, err)
+	})
+	t.Run("opts carrying a value bypass the cache", func(t *testing.T) {
+		withBaseFee := feetypes.WithBaseFee(assets.NewWeiI(10))
+		baseFeeFee := gas.EvmFee{Legacy: assets.NewWeiI(30)}
+		est := newEstimator(t, 1)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything, withBaseFee).Return(baseFeeFee, uint32(100), nil).Twice()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.SetFeeCacheTTL(time.Minute)
+		_, f, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, fee, f)
+		// not served from the cache
+		for i := 0; i < 2; i++ {
+			_, f, _, _, err = cks.NewTxAttempt(ctx, etx, lggr, withBaseFee)
+			require.NoError(t, err)
+			assert.Equal(t, baseFeeFee, f)
+		}
+		// and not written to it either
+		_, f, _, _, err = cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, fee, f)
+	})
+	t.Run("a new head invalidates the cache", func(t *testing.T) {
+		est := newEstimator(t, 2)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		head := evmtypes.NewHead(big.NewInt(1), testutils.NewHash(), testutils.NewHash(), 0, nil)
+		est.On("OnNewLongestChain", mock.Anything, &head).Once()
+		cks.SetFeeCacheTTL(time.Minute)
+		_, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+		cks.OnNewLongestChain(ctx, &head)
+		_, _, _, _, err = cks.NewTxAttempt(ctx, etx, lggr)
+		require.NoError(t, err)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_WithExactFee(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	// no expectations set: the estimator must not be called
+	est := gasmocks.NewEvmFeeEstimator(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.priceMin = assets.NewWeiI(10)
+	gc.priceMax = assets.NewWeiI(50)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, FeeLimit: 100}
+
+	t.Run("builds with the pinned fee", func(t *testing.T) {
+		pinned := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+		a, fee, feeLimit, _, err := cks.NewTxAttemptWithType(ctx, etx, lggr, 0x0, feetypes.WithExactFee(pinned))
+		require.NoError(t, err)
+		assert.Equal(t, pinned, fee)
+		assert.Equal(t, uint32(100), feeLimit)
+		assert.Equal(t, pinned, a.TxFee)
+		assert.Equal(t, uint32(100), a.ChainSpecificFeeLimit)
+	})
+	t.Run("validates the pinned fee", func(t *testing.T) {
+		_, _, _, _, err := cks.NewTxAttemptWithType(ctx, etx, lggr, 0x0, feetypes.WithExactFee(gas.EvmFee{Legacy: assets.NewWeiI(100)}))
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+		_, _, _, _, err = cks.NewTxAttemptWithType(ctx, etx, lggr, 0x0, feetypes.WithExactFee(gas.EvmFee{Legacy: assets.NewWeiI(5)}))
+		require.ErrorIs(t, err, txmgr.ErrFeeBelowMin)
+	})
+	t.Run("rejects a pinned fee that does not match the tx type", func(t *testing.T) {
+		_, _, _, retryable, err := cks.NewTxAttemptWithType(ctx, etx, lggr, 0x2, feetypes.WithExactFee(gas.EvmFee{Legacy: assets.NewWeiI(25)}))
+		require.ErrorContains(t, err, "did not return dynamic fee bump")
+		assert.False(t, retryable)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_LimitDefault(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.limitDefault = 500_000
+	gc.priceMax = assets.NewWeiI(50)
+	var n evmtypes.Nonce
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+
+	t.Run("substitutes the default for a zero fee limit", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		// the estimator still gets to cap the limit
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, uint32(500_000), mock.Anything).Return(fee, uint32(400_000), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		a, _, feeLimit, _, err := cks.NewTxAttemptWithType(ctx, txmgr.Tx{Sequence: &n, FromAddress: addr}, lggr, 0x0)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(400_000), feeLimit)
+		assert.Equal(t, uint32(400_000), a.ChainSpecificFeeLimit)
+	})
+	t.Run("passes a non-zero fee limit through", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, uint32(21_000), mock.Anything).Return(fee, uint32(21_000), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		_, _, feeLimit, _, err := cks.NewTxAttemptWithType(ctx, txmgr.Tx{Sequence: &n, FromAddress: addr, FeeLimit: 21_000}, lggr, 0x0)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(21_000), feeLimit)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_CancelledContext(t *testing.T) {
+	// no expectations set: the estimator must not be called
+	est := gasmocks.NewEvmFeeEstimator(t)
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+
+	ctx, cancel := context.WithCancel(testutils.Context(t))
+	cancel()
+
+	t.Run("NewTxAttemptWithType", func(t *testing.T) {
+		_, _, _, retryable, err := cks.NewTxAttemptWithType(ctx, txmgr.Tx{}, lggr, 0x0)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.True(t, retryable)
+	})
+	t.Run("NewBumpTxAttempt", func(t *testing.T) {
+		_, _, _, retryable, err := cks.NewBumpTxAttempt(ctx, txmgr.Tx{}, txmgr.TxAttempt{}, nil, lggr)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.True(t, retryable)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_PassesToAddressToEstimator(t *testing.T) {
+	toAddress := testutils.NewAddress()
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("GetFeeForType", mock.Anything, 0x0, toAddress, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{}, uint32(0), errors.New("fail")).Once()
+
+	kst := ksmocks.NewEth(t)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+
+	_, _, _, _, err := cks.NewTxAttempt(testutils.Context(t), txmgr.Tx{ToAddress: toAddress}, logger.TestLogger(t))
+	require.Error(t, err)
+}
+
+func TestTxm_EvmTxAttemptBuilder_L1OracleForTx(t *testing.T) {
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	defaultOracle := rollupsmocks.NewL1Oracle(t)
+	overrideOracle := rollupsmocks.NewL1Oracle(t)
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("L1Oracle").Return(defaultOracle)
+
+	b, err := json.Marshal(txmgr.TxMeta{L1OracleOverride: true})
+	require.NoError(t, err)
+	meta := datatypes.JSON(b)
+	flaggedTx := txmgr.Tx{Meta: &meta}
+
+	t.Run("uses the default oracle when no override is set", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+		assert.Same(t, defaultOracle, cks.L1OracleForTx(flaggedTx, lggr))
+	})
+
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+	cks.SetL1OracleOverride(overrideOracle)
+
+	t.Run("uses the override oracle for flagged txs", func(t *testing.T) {
+		assert.Same(t, overrideOracle, cks.L1OracleForTx(flaggedTx, lggr))
+	})
+	t.Run("uses the default oracle for unflagged txs", func(t *testing.T) {
+		assert.Same(t, defaultOracle, cks.L1OracleForTx(txmgr.Tx{}, lggr))
+
+		b, err := json.Marshal(txmgr.TxMeta{})
+		require.NoError(t, err)
+		meta := datatypes.JSON(b)
+		assert.Same(t, defaultOracle, cks.L1OracleForTx(txmgr.Tx{Meta: &meta}, lggr))
+	})
+}
+
+func TestTxm_TxAttempt_Clone(t *testing.T) {
+	original := txmgr.TxAttempt{
+		TxFee:       gas.EvmFee{Legacy: assets.NewWeiI(10), DynamicFeeCap: assets.NewWeiI(20), DynamicTipCap: assets.NewWeiI(5)},
+		SignedRawTx: []byte{1, 2, 3},
+		TxType:      0x2,
+	}
+	clone := original.Clone()
+	require.Equal(t, original, clone)
+
+	clone.TxFee.Legacy.ToInt().SetInt64(100)
+	clone.TxFee.DynamicFeeCap.ToInt().SetInt64(200)
+	clone.TxFee.DynamicTipCap.ToInt().SetInt64(50)
+	clone.SignedRawTx[0] = 9
+
+	assert.Equal(t, assets.NewWeiI(10), original.TxFee.Legacy)
+	assert.Equal(t, assets.NewWeiI(20), original.TxFee.DynamicFeeCap)
+	assert.Equal(t, assets.NewWeiI(5), original.TxFee.DynamicTipCap)
+	assert.Equal(t, []byte{1, 2, 3}, original.SignedRawTx)
+
+	t.Run("nil fee components stay nil", func(t *testing.T) {
+		clone := txmgr.TxAttempt{TxFee: gas.EvmFee{Legacy: assets.NewWeiI(1)}}.Clone()
+		assert.Nil(t, clone.TxFee.DynamicFeeCap)
+		assert.Nil(t, clone.TxFee.DynamicTipCap)
+		assert.Nil(t, clone.SignedRawTx)
+	})
+}
+
+func TestTxm_TxAttempt_MarshalBinary(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, txmgr.NewTestDeterministicSigner(key), nil)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{ID: 42, Sequence: &n, FromAddress: addr, ToAddress: NewEvmAddress(), EncodedPayload: []byte{0xde, 0xad}}
+
+	for _, tc := range []struct {
+		name   string
+		txType int
+		fee    gas.EvmFee
+	}{
+		{"legacy", 0x0, gas.NewLegacyFee(assets.GWei(25))},
+		{"dynamic", 0x2, gas.NewDynamicFee(assets.GWei(50), assets.GWei(2))},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			a, _, err := cks.NewCustomTxAttempt(etx, tc.fee, 21000, tc.txType, lggr)
+			require.NoError(t, err)
+
+			b, err := a.MarshalBinary()
+			require.NoError(t, err)
+			again, err := a.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, b, again, "encoding must be deterministic")
+			assert.Equal(t, txmgrtypes.TxAttemptEncodingVersion, b[0])
+
+			var decoded txmgr.TxAttempt
+			require.NoError(t, decoded.UnmarshalBinary(b))
+			assert.Equal(t, txmgr.TxAttempt{
+				TxID:                  42,
+				TxFee:                 tc.fee,
+				ChainSpecificFeeLimit: 21000,
+				SignedRawTx:           a.SignedRawTx,
+				Hash:                  a.Hash,
+				TxType:                tc.txType,
+			}, decoded)
+
+			reencoded, err := decoded.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, b, reencoded)
+		})
+	}
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		a := txmgr.TxAttempt{TxID: 1, TxFee: gas.NewLegacyFee(assets.GWei(1)), SignedRawTx: []byte{1}}
+		b, err := a.MarshalBinary()
+		require.NoError(t, err)
+
+		b[0] = txmgrtypes.TxAttemptEncodingVersion + 1
+		var decoded txmgr.TxAttempt
+		require.ErrorContains(t, decoded.UnmarshalBinary(b), "unsupported encoding version 2, expected 1")
+		assert.Equal(t, txmgr.TxAttempt{}, decoded)
+	})
+
+	t.Run("rejects truncated data", func(t *testing.T) {
+		a := txmgr.TxAttempt{TxID: 1, TxFee: gas.NewLegacyFee(assets.GWei(1)), SignedRawTx: []byte{1, 2, 3}}
+		b, err := a.MarshalBinary()
+		require.NoError(t, err)
+
+		var decoded txmgr.TxAttempt
+		require.ErrorContains(t, decoded.UnmarshalBinary(b[:len(b)-1]), "data too short")
+		require.ErrorContains(t, decoded.UnmarshalBinary(append(b, 0)), "trailing bytes")
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_AttemptMeta(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, txmgr.NewTestDeterministicSigner(key), nil)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{ID: 42, Sequence: &n, FromAddress: addr, ToAddress: NewEvmAddress()}
+	meta := datatypes.JSON(`{"JobID":7,"PipelineTaskRunID":"run-1","FailOnRevert":false,"RequestID":null}`)
+	withMeta := etx
+	withMeta.Meta = &meta
+
+	without, _, err := cks.NewCustomTxAttempt(etx, gas.NewLegacyFee(assets.GWei(25)), 21000, 0x0, lggr)
+	require.NoError(t, err)
+	assert.Nil(t, without.Meta)
+
+	with, _, err := cks.NewCustomTxAttempt(withMeta, gas.NewLegacyFee(assets.GWei(25)), 21000, 0x0, lggr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"JobID": "7", "PipelineTaskRunID": "run-1", "FailOnRevert": "false"}, with.Meta)
+	assert.Equal(t, without.SignedRawTx, with.SignedRawTx, "meta must not be signed")
+	assert.Equal(t, without.Hash, with.Hash)
+
+	empty, err := cks.NewEmptyTxAttempt(n, 21000, gas.NewLegacyFee(assets.GWei(25)), addr)
+	require.NoError(t, err)
+	assert.Nil(t, empty.Meta)
+
+	cloned := with.Clone()
+	cloned.Meta["JobID"] = "8"
+	assert.Equal(t, "7", with.Meta["JobID"], "clones must not share meta")
+}
+
+func TestTxm_EvmTxAttemptBuilder_MaxPayloadBytes(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+
+	t.Run("accepts a payload at the limit", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		gc.maxPayloadBytes = 4
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr, EncodedPayload: make([]byte, 4)}, fee, 100, 0x0, lggr)
+		require.NoError(t, err)
+	})
+	t.Run("rejects a payload over the limit without signing", func(t *testing.T) {
+		gc.maxPayloadBytes = 4
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		_, retryable, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr, EncodedPayload: make([]byte, 5)}, fee, 100, 0x0, lggr)
+		require.ErrorIs(t, err, txmgr.ErrPayloadTooLarge)
+		assert.False(t, retryable)
+	})
+	t.Run("does not limit the payload when disabled", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		gc.maxPayloadBytes = 0
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr, EncodedPayload: make([]byte, 1<<16)}, fee, 100, 0x0, lggr)
+		require.NoError(t, err)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_ZeroGasLimit(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{ID: 1, Sequence: &n, FromAddress: addr}
+
+	t.Run("rejects a custom attempt with a zero gas limit without signing", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, nil)
+		_, retryable, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.NewWeiI(25)}, 0, 0x0, lggr)
+		require.ErrorIs(t, err, txmgr.ErrZeroGasLimit)
+		assert.False(t, retryable)
+	})
+	t.Run("rejects a zero gas limit returned by the estimator", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(0), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, est)
+		_, _, _, retryable, err := cks.NewTxAttemptWithType(testutils.Context(t), etx, lggr, 0x0)
+		require.ErrorIs(t, err, txmgr.ErrZeroGasLimit)
+		assert.False(t, retryable)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewTxAttemptFromEstimate(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{ID: 1, Sequence: &n, FromAddress: addr}
+	cfg := newFeeConfig()
+	cfg.priceMax = assets.GWei(200)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), cfg, kst, nil)
+
+	legacy := gas.EvmFee{Legacy: assets.GWei(25)}
+	dynamic := gas.EvmFee{DynamicFeeCap: assets.GWei(50), DynamicTipCap: assets.GWei(2)}
+	mixed := gas.EvmFee{Legacy: assets.GWei(25), DynamicFeeCap: assets.GWei(50), DynamicTipCap: assets.GWei(2)}
+	tipOnly := gas.EvmFee{DynamicTipCap: assets.GWei(2)}
+
+	for _, tc := range []struct {
+		name   string
+		fee    gas.EvmFee
+		txType int
+	}{
+		{"legacy fee with dynamic type", legacy, 0x2},
+		{"dynamic fee with legacy type", dynamic, 0x0},
+		{"mixed fee with legacy type", mixed, 0x0},
+		{"mixed fee with dynamic type", mixed, 0x2},
+		{"empty fee with legacy type", gas.EvmFee{}, 0x0},
+		{"empty fee with dynamic type", gas.EvmFee{}, 0x2},
+		{"tip cap only with dynamic type", tipOnly, 0x2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, retryable, err := cks.NewTxAttemptFromEstimate(etx, tc.fee, 100_000, tc.txType, lggr)
+			require.ErrorIs(t, err, txmgr.ErrFeeTypeMismatch)
+			assert.False(t, retryable)
+		})
+	}
+
+	t.Run("rejects an unrecognized tx type", func(t *testing.T) {
+		_, retryable, err := cks.NewTxAttemptFromEstimate(etx, legacy, 100_000, 0x3, lggr)
+		var typeErr *txmgr.ErrUnrecognizedTxType
+		require.ErrorAs(t, err, &typeErr)
+		assert.Equal(t, 0x3, typeErr.TxType())
+		assert.False(t, retryable)
+	})
+
+	t.Run("builds matching pairs", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(
+			func(_ gethcommon.Address, tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+				return tx, nil
+			})
+
+		a, _, err := cks.NewTxAttemptFromEstimate(etx, legacy, 100_000, 0x0, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x0, a.TxType)
+		assert.Equal(t, legacy.Legacy, a.TxFee.Legacy)
+		assert.Equal(t, uint32(100_000), a.ChainSpecificFeeLimit)
+
+		a, _, err = cks.NewTxAttemptFromEstimate(etx, dynamic, 100_000, 0x2, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, dynamic.DynamicFeeCap, a.TxFee.DynamicFeeCap)
+		assert.Equal(t, dynamic.DynamicTipCap, a.TxFee.DynamicTipCap)
+	})
+}
+
+func TestCheckBatchSequences(t *testing.T) {
+	a, b := NewEvmAddress(), NewEvmAddress()
+	seq := func(n int64) *evmtypes.Nonce { s := evmtypes.Nonce(n); return &s }
+
+	t.Run("accepts distinct pairs", func(t *testing.T) {
+		require.NoError(t, txmgr.CheckBatchSequences([]txmgr.Tx{
+			{FromAddress: a, Sequence: seq(1)},
+			{FromAddress: a, Sequence: seq(2)},
+			{FromAddress: b, Sequence: seq(1)},
+			{FromAddress: a},
+			{FromAddress: a},
+		}))
+	})
+	t.Run("rejects a duplicate pair naming both indices", func(t *testing.T) {
+		err := txmgr.CheckBatchSequences([]txmgr.Tx{
+			{FromAddress: a, Sequence: seq(1)},
+			{FromAddress: b, Sequence: seq(7)},
+			{FromAddress: a, Sequence: seq(2)},
+			{FromAddress: b, Sequence: seq(7)},
+		})
+		require.ErrorIs(t, err, txmgr.ErrDuplicateSequence)
+		assert.Contains(t, err.Error(), "indices 1 and 3")
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_OptSkipValidation(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, FeeLimit: 100_000}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+	for _, tc := range []struct {
+		name   string
+		fee    gas.EvmFee
+		txType int
+	}{
+		{"legacy", gas.EvmFee{Legacy: assets.GWei(150)}, 0x0},
+		{"dynamic", gas.EvmFee{DynamicFeeCap: assets.GWei(150), DynamicTipCap: assets.GWei(2)}, 0x2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			exact := feetypes.WithExactFee(tc.fee)
+
+			_, _, _, retryable, err := cks.NewTxAttemptWithType(ctx, etx, lggr, tc.txType, exact)
+			require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+			assert.True(t, retryable)
+
+			kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+			a, _, _, _, err := cks.NewTxAttemptWithType(ctx, etx, lggr, tc.txType, exact, feetypes.OptSkipValidation)
+			require.NoError(t, err)
+			assert.Equal(t, tc.txType, a.TxType)
+		})
+	}
+}
+
+func TestTxm_EvmTxAttemptBuilder_SignerCircuitBreaker(t *testing.T) {
+	addr, other := NewEvmAddress(), NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), newFeeConfig(), kst, nil)
+	cooldown := 200 * time.Millisecond
+	cks.SetSignerCircuitBreaker(2, cooldown)
+	build := func(from gethcommon.Address) error {
+		_, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: from}, fee, 100, 0x0, lggr)
+		return err
+	}
+	hsmErr := errors.New("hsm unreachable")
+
+	// the breaker opens after 2 consecutive failures, and the keystore is not called while it is open
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(nil, hsmErr).Twice()
+	require.ErrorIs(t, build(addr), hsmErr)
+	require.ErrorIs(t, build(addr), hsmErr)
+	require.ErrorIs(t, build(addr), txmgr.ErrSignerUnavailable)
+
+	// other keys are unaffected
+	kst.On("SignTx", other, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+	require.NoError(t, build(other))
+
+	// a failure after the cooldown reopens it straight away
+	time.Sleep(cooldown)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(nil, hsmErr).Once()
+	require.ErrorIs(t, build(addr), hsmErr)
+	require.ErrorIs(t, build(addr), txmgr.ErrSignerUnavailable)
+
+	// a success after the cooldown closes it and resets the failure count
+	time.Sleep(cooldown)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+	require.NoError(t, build(addr))
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(nil, hsmErr).Once()
+	require.ErrorIs(t, build(addr), hsmErr)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+	require.NoError(t, build(addr))
+
+	// context errors are not signer failures, so they never open the breaker
+	for _, ctxErr := range []error{context.Canceled, context.DeadlineExceeded} {
+		kst.On("SignTx", other, mock.Anything, big.NewInt(1)).Return(nil, ctxErr).Twice()
+		require.ErrorIs(t, build(other), ctxErr)
+		require.ErrorIs(t, build(other), ctxErr)
+	}
+	kst.On("SignTx", other, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+	require.NoError(t, build(other))
+}
+
+func TestTxm_EvmTxAttemptBuilder_RecoverSender(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	fee := gas.EvmFee{Legacy: assets.NewWeiI(25)}
+	chainID := big.NewInt(1)
+
+	// signWith returns a keystore that signs for addr with key, which may not be addr's key
+	signWith := func(key *ecdsa.PrivateKey) *ksmocks.Eth {
+		kst := ksmocks.NewEth(t)
+		kst.On("SignTx", addr, mock.Anything, chainID).Return(
+			func(_ gethcommon.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+				return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+			})
+		return kst
+	}
+
+	t.Run("recovers the signer", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), signWith(key), nil)
+		cks.SetVerifySender(true)
+		a, _, err := cks.NewCustomTxAttempt(etx, fee, 100, 0x0, lggr)
+		require.NoError(t, err)
+		sender, err := cks.RecoverSender(a)
+		require.NoError(t, err)
+		assert.Equal(t, addr, sender)
+	})
+
+	t.Run("mismatched signer", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), signWith(otherKey), nil)
+		a, _, err := cks.NewCustomTxAttempt(etx, fee, 100, 0x0, lggr)
+		require.NoError(t, err, "the sender is only verified when enabled")
+		sender, err := cks.RecoverSender(a)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(otherKey.PublicKey), sender)
+
+		cks.SetVerifySender(true)
+		_, _, err = cks.NewCustomTxAttempt(etx, fee, 100, 0x0, lggr)
+		require.ErrorIs(t, err, txmgr.ErrSenderMismatch)
+	})
+
+	t.Run("errors on an undecodable attempt", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*chainID, newFeeConfig(), ksmocks.NewEth(t), nil)
+		_, err := cks.RecoverSender(txmgr.TxAttempt{SignedRawTx: []byte{1, 2, 3}})
+		require.Error(t, err)
+	})
+}
+
+type stubGasUsedHistory map[[4]byte]uint32
+
+func (h stubGasUsedHistory) GasUsedPercentile(_ gethcommon.Address, selector [4]byte, _ float64) (uint32, bool) {
+	gasUsed, ok := h[selector]
+	return gasUsed, ok
+}
+
+func TestTxm_EvmTxAttemptBuilder_GasUsedHistory(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	known, unknown := []byte{0xa9, 0x05, 0x9c, 0xbb, 1, 2}, []byte{0xde, 0xad, 0xbe, 0xef}
+	history := stubGasUsedHistory{{0xa9, 0x05, 0x9c, 0xbb}: 50_000}
+
+	for _, tc := range []struct {
+		name     string
+		history  txmgr.GasUsedHistory
+		payload  []byte
+		feeLimit uint32
+	}{
+		{"sizes the fee limit from history with a margin", history, known, 60_000},
+		{"keeps the tx fee limit without history for the call", history, unknown, 500_000},
+		{"keeps the tx fee limit without a history", nil, known, 500_000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			est := gasmocks.NewEvmFeeEstimator(t)
+			est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, tc.payload, tc.feeLimit, mock.Anything).
+				Return(gas.EvmFee{Legacy: assets.GWei(25)}, tc.feeLimit, nil).Once()
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+			if tc.history != nil {
+				cks.SetGasUsedHistory(tc.history, 90, 20)
+			}
+
+			a, _, feeLimit, _, err := cks.NewTxAttempt(ctx, txmgr.Tx{Sequence: &n, FromAddress: addr, EncodedPayload: tc.payload, FeeLimit: 500_000}, lggr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.feeLimit, feeLimit)
+			assert.Equal(t, tc.feeLimit, a.ChainSpecificFeeLimit)
+		})
+	}
+}
+
+func TestTxm_EvmTxAttemptBuilder_MaxTotalCost(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	gc := newFeeConfig()
+	gc.eip1559DynamicFees = true
+	gc.priceMax = assets.GWei(100)
+	// value 1 gwei + 100_000 gas x 10 gwei = 1_000_001 gwei
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, Value: *big.NewInt(1e9)}
+	legacy := gas.EvmFee{Legacy: assets.GWei(10)}
+	dynamic := gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: assets.GWei(1)}
+
+	for _, tc := range []struct {
+		name   string
+		max    *assets.Wei
+		fee    gas.EvmFee
+		txType int
+		ok     bool
+	}{
+		{"no max", nil, legacy, 0x0, true},
+		{"legacy at the max", assets.GWei(1_000_001), legacy, 0x0, true},
+		{"legacy one wei over the max", assets.GWei(1_000_001).Sub(assets.NewWeiI(1)), legacy, 0x0, false},
+		{"dynamic at the max", assets.GWei(1_000_001), dynamic, 0x2, true},
+		{"dynamic one wei over the max", assets.GWei(1_000_001).Sub(assets.NewWeiI(1)), dynamic, 0x2, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gc.maxTotalCost = tc.max
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+			_, retryable, err := cks.NewCustomTxAttempt(etx, tc.fee, 100_000, tc.txType, lggr)
+			if tc.ok {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, txmgr.ErrTotalCostExceedsMax)
+			assert.False(t, retryable)
+		})
+	}
+}
+
+func TestIntrinsicGas(t *testing.T) {
+	accessList := types.AccessList{
+		{Address: testutils.NewAddress(), StorageKeys: []gethcommon.Hash{{1}, {2}}},
+		{Address: testutils.NewAddress()},
+	}
+	for _, tc := range []struct {
+		name       string
+		payload    []byte
+		txType     int
+		accessList types.AccessList
+		exp        uint64
+	}{
+		{"empty legacy", nil, 0x0, nil, 21_000},
+		{"empty dynamic", nil, 0x2, nil, 21_000},
+		{"zero and non-zero bytes", []byte{0, 1, 0, 2, 3}, 0x0, nil, 21_000 + 2*4 + 3*16},
+		{"access list", nil, 0x2, accessList, 21_000 + 2*2400 + 2*1900},
+		{"data and access list", []byte{0, 1}, 0x1, accessList, 21_000 + 4 + 16 + 2*2400 + 2*1900},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := txmgr.IntrinsicGas(txmgr.Tx{EncodedPayload: tc.payload}, tc.txType, tc.accessList...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, g)
+		})
+	}
+
+	t.Run("rejects an access list on a legacy tx", func(t *testing.T) {
+		_, err := txmgr.IntrinsicGas(txmgr.Tx{}, 0x0, accessList...)
+		require.Error(t, err)
+	})
+	t.Run("rejects an unknown tx type", func(t *testing.T) {
+		_, err := txmgr.IntrinsicGas(txmgr.Tx{}, 0x3)
+		require.Error(t, err)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_OnReorg(t *testing.T) {
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	kst := ksmocks.NewEth(t)
+
+	h1 := &evmtypes.Head{Number: 1, Hash: testutils.NewHash()}
+	h2 := &evmtypes.Head{Number: 2, Hash: testutils.NewHash(), ParentHash: h1.Hash, Parent: h1}
+	h3 := &evmtypes.Head{Number: 3, Hash: testutils.NewHash(), ParentHash: h2.Hash, Parent: h2}
+
+	t.Run("does not signal a reorg when the chain is extended", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("OnNewLongestChain", mock.Anything, mock.Anything).Twice()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.OnNewLongestChain(ctx, h2)
+		cks.OnNewLongestChain(ctx, h3)
+	})
+
+	t.Run("signals a reorg with the common ancestor", func(t *testing.T) {
+		reorged2 := &evmtypes.Head{Number: 2, Hash: testutils.NewHash(), ParentHash: h1.Hash, Parent: h1}
+		reorged3 := &evmtypes.Head{Number: 3, Hash: testutils.NewHash(), ParentHash: reorged2.Hash, Parent: reorged2}
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("OnNewLongestChain", mock.Anything, mock.Anything).Twice()
+		est.On("OnReorg", mock.Anything, reorged3, h1).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.OnNewLongestChain(ctx, h3)
+		cks.OnNewLongestChain(ctx, reorged3)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_StripAccessList(t *testing.T) {
+	addr := NewEvmAddress()
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, ToAddress: testutils.NewAddress()}
+
+	accessList := types.AccessList{{Address: testutils.NewAddress(), StorageKeys: []gethcommon.Hash{{1}}}}
+	prev := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    big.NewInt(1),
+		GasTipCap:  big.NewInt(10),
+		GasFeeCap:  big.NewInt(100),
+		AccessList: accessList,
+	})
+	prevRaw, err := prev.MarshalBinary()
+	require.NoError(t, err)
+	previous := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicTipCap: assets.NewWeiI(10), DynamicFeeCap: assets.NewWeiI(100)}, SignedRawTx: prevRaw}
+
+	for _, tc := range []struct {
+		name     string
+		strip    bool
+		expected types.AccessList
+	}{
+		{"carries over the previous access list by default", false, accessList},
+		{"strips the previous access list when enabled", true, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kst := ksmocks.NewEth(t)
+			kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool {
+				return assert.ObjectsAreEqual(tc.expected, tx.AccessList())
+			}), big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+			est := gasmocks.NewEvmFeeEstimator(t)
+			est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{DynamicTipCap: assets.NewWeiI(20), DynamicFeeCap: assets.NewWeiI(200)}, uint32(100), nil).Once()
+			gc := newFeeConfig()
+			gc.priceMax = assets.NewWeiI(1000)
+			gc.stripAccessListOnBump = tc.strip
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+			_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTxm_EvmTxAttemptBuilder_BuildDeterministic(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	n := evmtypes.Nonce(7)
+	etx := txmgr.Tx{
+		Sequence:       &n,
+		FromAddress:    crypto.PubkeyToAddress(key.PublicKey),
+		ToAddress:      gethcommon.HexToAddress("0x0000000000000000000000000000000000000abc"),
+		EncodedPayload: []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	// golden values, any change to how attempts are built or encoded must be deliberate
+	for _, tc := range []struct {
+		name   string
+		fee    gas.EvmFee
+		txType int
+		raw    string
+		hash   string
+	}{
+		{
+			"legacy", gas.EvmFee{Legacy: assets.GWei(25)}, 0x0,
+			"f868078505d21dba00825208940000000000000000000000000000000000000abc8084deadbeef25a0bc36555e6b6d42f4eab74dc1f0276d0ccefc583e940ba9f10ba603d37c1647a6a019477014475b4eea585fc5a174fc3e628feb0d6d36249d17e9478924c8277813",
+			"0x95751493e4f5f9ab66dc25243d8b75d8c57b74012c6ab3c397e341ff4e8a8a57",
+		},
+		{
+			"dynamic", gas.EvmFee{DynamicTipCap: assets.GWei(2), DynamicFeeCap: assets.GWei(50)}, 0x2,
+			"b87202f86f01078477359400850ba43b7400825208940000000000000000000000000000000000000abc8084deadbeefc001a0e9fb14e5b5c359e552803cb8cf759aac8cda513b34338b52f5ac8cf822af775ba053c41ff3d147386f271d325e98443ab96294b9ee7b843ebcfa9274da686af5c0",
+			"0xe4b3770640da11d54ae95dd790585597c1069cd9b4e62f96ec9d04f7ad5bfe37",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, txmgr.NewTestDeterministicSigner(key), nil)
+			cks.SetBuildDeterministic(true)
+			for i := 0; i < 2; i++ {
+				a, _, err := cks.NewCustomTxAttempt(etx, tc.fee, 21000, tc.txType, lggr)
+				require.NoError(t, err)
+				assert.Equal(t, tc.raw, fmt.Sprintf("%x", a.SignedRawTx))
+				assert.Equal(t, tc.hash, a.Hash.String())
+			}
+		})
+	}
+
+	t.Run("refuses to sign with a non-deterministic signer", func(t *testing.T) {
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, ksmocks.NewEth(t), nil)
+		cks.SetBuildDeterministic(true)
+		_, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(25)}, 21000, 0x0, lggr)
+		require.ErrorContains(t, err, "deterministic builds require a deterministic signer")
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_UpgradeLegacy(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	previous := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.GWei(10)}}
+
+	t.Run("upgrades a legacy attempt to dynamic fees when EIP-1559 is enabled", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Type() == 0x2 }), big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		est := gasmocks.NewEvmFeeEstimator(t)
+		converted := gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: assets.GWei(2)}
+		bumped := gas.EvmFee{DynamicFeeCap: assets.GWei(11), DynamicTipCap: assets.GWei(3)}
+		est.On("BumpFee", mock.Anything, converted, mock.Anything, mock.Anything, mock.Anything).Return(bumped, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.eip1559DynamicFees = true
+		gc.priceMax = assets.GWei(100)
+		gc.tipCapDefault = assets.GWei(2)
+		gc.upgradeLegacyOnBump = true
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, []txmgr.TxAttempt{previous}, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, bumped, fee)
+		assert.Nil(t, a.TxFee.Legacy)
+	})
+
+	t.Run("derives the converted tip cap from TipCapMin and caps it at the gas price", func(t *testing.T) {
+		for _, tt := range []struct {
+			name          string
+			tipCapDefault *assets.Wei
+			tipCapMin     *assets.Wei
+			expectedTip   *assets.Wei
+		}{
+			{"raised to TipCapMin", assets.GWei(1), assets.GWei(3), assets.GWei(3)},
+			{"capped at the gas price", assets.GWei(20), assets.GWei(1), assets.GWei(10)},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+				est := gasmocks.NewEvmFeeEstimator(t)
+				converted := gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: tt.expectedTip}
+				est.On("BumpFee", mock.Anything, converted, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{DynamicFeeCap: assets.GWei(11), DynamicTipCap: assets.GWei(11)}, uint32(100), nil).Once()
+				gc := newFeeConfig()
+				gc.eip1559DynamicFees = true
+				gc.priceMax = assets.GWei(100)
+				gc.tipCapDefault = tt.tipCapDefault
+				gc.tipCapMin = tt.tipCapMin
+				gc.upgradeLegacyOnBump = true
+				cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+				_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+				require.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("leaves MinFeeCapTipMarginWei between the converted fee cap and tip cap", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		est := gasmocks.NewEvmFeeEstimator(t)
+		converted := gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: assets.GWei(7)}
+		bumped := gas.EvmFee{DynamicFeeCap: assets.GWei(11), DynamicTipCap: assets.GWei(7).AddPercentage(10)}
+		est.On("BumpFee", mock.Anything, converted, mock.Anything, mock.Anything, mock.Anything).Return(bumped, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.eip1559DynamicFees = true
+		gc.priceMax = assets.GWei(100)
+		gc.tipCapDefault = assets.GWei(20)
+		gc.minFeeCapTipMargin = assets.GWei(3)
+		gc.upgradeLegacyOnBump = true
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, bumped, fee)
+	})
+
+	t.Run("bumps only the tip cap of an upgraded attempt with BumpTipOnly", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		est := gasmocks.NewEvmFeeEstimator(t)
+		converted := gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: assets.GWei(2)}
+		est.On("BumpFee", mock.Anything, converted, mock.Anything, mock.Anything, mock.Anything, feetypes.OptBumpTipOnly).Return(gas.EvmFee{DynamicFeeCap: assets.GWei(10), DynamicTipCap: assets.GWei(3)}, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.eip1559DynamicFees = true
+		gc.priceMax = assets.GWei(100)
+		gc.tipCapDefault = assets.GWei(2)
+		gc.upgradeLegacyOnBump = true
+		gc.bumpTipOnly = true
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, assets.GWei(10), fee.DynamicFeeCap)
+		assert.Equal(t, assets.GWei(3), fee.DynamicTipCap)
+	})
+
+	t.Run("keeps legacy attempts when EIP-1559 is disabled", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Type() == 0x0 }), big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, previous.TxFee, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.GWei(11)}, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		gc.upgradeLegacyOnBump = true
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		a, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x0, a.TxType)
+		assert.Equal(t, assets.GWei(11), a.TxFee.Legacy)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_ChainID(t *testing.T) {
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(42161), newFeeConfig(), ksmocks.NewEth(t), nil)
+	chainID := cks.ChainID()
+	assert.Equal(t, big.NewInt(42161).String(), chainID.String())
+
+	chainID.SetInt64(1)
+	again := cks.ChainID()
+	assert.Equal(t, "42161", again.String())
+}
+
+func TestTxm_EvmTxAttemptBuilder_ConfigSnapshot(t *testing.T) {
+	gc := newFeeConfig()
+	gc.eip1559DynamicFees = true
+	gc.tipCapMin = assets.GWei(1)
+	gc.priceMin = assets.GWei(2)
+	gc.priceMax = assets.GWei(300)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, ksmocks.NewEth(t), nil)
+
+	assert.Equal(t, txmgr.BuilderConfigSnapshot{
+		EIP1559DynamicFees: true,
+		TipCapMin:          assets.GWei(1),
+		PriceMin:           assets.GWei(2),
+		PriceMaxKey:        assets.GWei(300),
+	}, cks.ConfigSnapshot(NewEvmAddress()))
+}
+
+func TestTxm_EvmTxAttemptBuilder_Deadline(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(100)
+	var n evmtypes.Nonce
+	deadline := time.Unix(1700000000, 0)
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, FeeLimit: 100, Deadline: deadline}
+
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(25)}, uint32(100), nil).Once()
+	est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.NewWeiI(50)}, uint32(100), nil).Once()
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+	a, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+	require.NoError(t, err)
+	assert.Equal(t, deadline, a.Deadline)
+
+	bumped, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, a, nil, lggr)
+	require.NoError(t, err)
+	assert.Equal(t, deadline, bumped.Deadline)
+
+	t.Run("defaults to no deadline", func(t *testing.T) {
+		a, _, err := cks.NewCustomTxAttempt(txmgr.Tx{Sequence: &n, FromAddress: addr}, gas.EvmFee{Legacy: assets.NewWeiI(25)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		assert.True(t, a.Deadline.IsZero())
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_BumpTipOnly(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.eip1559DynamicFees = true
+	gc.priceMax = assets.GWei(100)
+	gc.bumpTipOnly = true
+	previous := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicFeeCap: assets.GWei(40), DynamicTipCap: assets.GWei(10)}}
+
+	t.Run("bumps the tip cap and keeps the fee cap", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, previous.TxFee, mock.Anything, mock.Anything, mock.Anything, feetypes.OptBumpTipOnly).Return(gas.EvmFee{DynamicFeeCap: assets.GWei(44), DynamicTipCap: assets.GWei(11)}, uint32(100), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(11), fee.DynamicTipCap)
+		assert.Equal(t, assets.GWei(40), fee.DynamicFeeCap)
+		assert.Equal(t, assets.GWei(40), a.TxFee.DynamicFeeCap)
+	})
+
+	t.Run("errors if the estimator cannot bump the tip cap below the fixed fee cap", func(t *testing.T) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, previous.TxFee, mock.Anything, mock.Anything, mock.Anything, feetypes.OptBumpTipOnly).Return(gas.EvmFee{}, uint32(0), errors.Wrap(commonfee.ErrBump, "cannot bump tip cap only")).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.ErrorIs(t, err, commonfee.ErrBump)
+	})
+
+	t.Run("errors if a min bump raises the tip cap above the fixed fee cap", func(t *testing.T) {
+		nearCap := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicFeeCap: assets.GWei(40), DynamicTipCap: assets.GWei(39)}}
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, nearCap.TxFee, mock.Anything, mock.Anything, mock.Anything, feetypes.OptBumpTipOnly).Return(gas.EvmFee{DynamicFeeCap: assets.GWei(40), DynamicTipCap: assets.GWei(40)}, uint32(100), nil).Once()
+		gc := *gc
+		gc.minBumpPercent = 10
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), &gc, kst, est)
+
+		_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, nearCap, nil, lggr)
+		require.ErrorContains(t, err, "gas fee cap must be greater than or equal to gas tip cap")
+	})
+
+	t.Run("bumps the tip cap with the fee cap near the key's max", func(t *testing.T) {
+		nearMax := txmgr.TxAttempt{TxType: 0x2, TxFee: gas.EvmFee{DynamicFeeCap: assets.GWei(95), DynamicTipCap: assets.GWei(10)}}
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		// a fee cap bump to 104.5 gwei would exceed the key's max of 100 gwei, so the estimator must only bump the tip
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, nearMax.TxFee, mock.Anything, gc.priceMax, mock.Anything, feetypes.OptBumpTipOnly).Return(gas.EvmFee{DynamicFeeCap: assets.GWei(95), DynamicTipCap: assets.GWei(11)}, uint32(100), nil).Once()
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, nearMax, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, assets.GWei(11), fee.DynamicTipCap)
+		assert.Equal(t, assets.GWei(95), fee.DynamicFeeCap)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_BumpGasLimit(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, FeeLimit: 100_000}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	previous := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.GWei(20)}, ChainSpecificFeeLimit: 100_000}
+
+	for _, tc := range []struct {
+		name      string
+		pct       uint16
+		max       uint32
+		previous  uint32
+		estimated uint32
+		exp       uint32
+	}{
+		{"no limit bump by default", 0, 0, 100_000, 100_000, 100_000},
+		{"bumps by the percentage", 20, 0, 100_000, 100_000, 120_000},
+		{"compounds over the previous attempt", 20, 0, 120_000, 100_000, 144_000},
+		{"bumps over a larger estimate", 20, 0, 100_000, 110_000, 132_000},
+		{"capped at the absolute max", 20, 110_000, 100_000, 100_000, 110_000},
+		{"never lowers the estimate below the max", 20, 90_000, 100_000, 100_000, 100_000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			est := gasmocks.NewEvmFeeEstimator(t)
+			est.On("BumpFee", mock.Anything, previous.TxFee, etx.FeeLimit, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.GWei(25)}, tc.estimated, nil).Once()
+			gc.bumpGasLimitPercent, gc.bumpGasLimitMax = tc.pct, tc.max
+			cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+			prev := previous
+			prev.ChainSpecificFeeLimit = tc.previous
+
+			a, _, limit, _, err := cks.NewBumpTxAttempt(ctx, etx, prev, nil, lggr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, limit)
+			assert.Equal(t, tc.exp, a.ChainSpecificFeeLimit)
+		})
+	}
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_Jitter(t *testing.T) {
+	key1, key2 := gethcommon.HexToAddress("0x01"), gethcommon.HexToAddress("0x02")
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", mock.Anything, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	previous := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.GWei(20)}}
+
+	bumpTo := func(t *testing.T, gc *feeConfig, bps uint16, key gethcommon.Address, bumped *assets.Wei) gas.EvmFee {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, previous.TxFee, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: bumped}, uint32(100), nil).Once()
+		gc.bumpJitterBasisPoints = bps
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		_, fee, _, _, err := cks.NewBumpTxAttempt(ctx, txmgr.Tx{Sequence: &n, FromAddress: key}, previous, nil, lggr)
+		require.NoError(t, err)
+		return fee
+	}
+	bump := func(t *testing.T, gc *feeConfig, bps uint16, key gethcommon.Address) gas.EvmFee {
+		return bumpTo(t, gc, bps, key, assets.GWei(30))
+	}
+
+	t.Run("no jitter by default", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		assert.Equal(t, assets.GWei(30), bump(t, gc, 0, key1).Legacy)
+	})
+
+	t.Run("different keys get different fees for the same bump", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		fee1, fee2 := bump(t, gc, 1000, key1), bump(t, gc, 1000, key2)
+		assert.NotEqual(t, fee1.Legacy.String(), fee2.Legacy.String())
+		for _, fee := range []gas.EvmFee{fee1, fee2} {
+			assert.True(t, fee.Legacy.Cmp(assets.GWei(27)) >= 0 && fee.Legacy.Cmp(assets.GWei(33)) <= 0, "fee %s outside of ±10%% of 30 gwei", fee.Legacy)
+		}
+		assert.Equal(t, fee1.Legacy.String(), bump(t, gc, 1000, key1).Legacy.String(), "jitter must be stable per key")
+	})
+
+	t.Run("keys with negative offsets still get different fees for a minimum bump", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		// both keys have a negative offset, which would take a bump to exactly 22 gwei below the 10% minimum
+		key4 := gethcommon.HexToAddress("0x04")
+		fee2, fee4 := bumpTo(t, gc, 1000, key2, assets.GWei(22)), bumpTo(t, gc, 1000, key4, assets.GWei(22))
+		assert.NotEqual(t, fee2.Legacy.String(), fee4.Legacy.String())
+		for _, fee := range []gas.EvmFee{fee2, fee4} {
+			assert.True(t, fee.Legacy.Cmp(assets.GWei(22)) > 0 && fee.Legacy.Cmp(assets.NewWeiI(24_200_000_000)) <= 0, "fee %s outside of 22 gwei +10%%", fee.Legacy)
+		}
+	})
+
+	t.Run("jittered fee is capped at the max gas price for the key", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(30)
+		for _, key := range []gethcommon.Address{key1, key2} {
+			assert.True(t, bump(t, gc, 1000, key).Legacy.Cmp(assets.GWei(30)) <= 0)
+		}
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_ValidateAttempt(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+	t.Run("legacy attempt exceeding a reduced max", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{Legacy: assets.GWei(50)}, 100, 0x0, lggr)
+		require.NoError(t, err)
+		require.NoError(t, cks.ValidateAttempt(a))
+
+		gc.priceMax = assets.GWei(40)
+		t.Cleanup(func() { gc.priceMax = assets.GWei(100) })
+		err = cks.ValidateAttempt(a)
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+		assert.Contains(t, err.Error(), fmt.Sprintf("specified gas price of 50 gwei would exceed max configured gas price of 40 gwei for key %s", addr))
+	})
+
+	t.Run("dynamic fee attempt exceeding a reduced max", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		a, _, err := cks.NewCustomTxAttempt(etx, gas.EvmFee{DynamicFeeCap: assets.GWei(50), DynamicTipCap: assets.GWei(2)}, 100, 0x2, lggr)
+		require.NoError(t, err)
+		require.NoError(t, cks.ValidateAttempt(a))
+
+		gc.priceMax = assets.GWei(40)
+		t.Cleanup(func() { gc.priceMax = assets.GWei(100) })
+		err = cks.ValidateAttempt(a)
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+		assert.Contains(t, err.Error(), "specified gas fee cap of 50 gwei would exceed max configured gas price of 40 gwei")
+	})
+
+	t.Run("attempt missing its fee", func(t *testing.T) {
+		require.Error(t, cks.ValidateAttempt(txmgr.TxAttempt{TxType: 0x2, Tx: etx}))
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_SignForChain(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, ToAddress: NewEvmAddress()}
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, txmgr.NewTestDeterministicSigner(key), nil)
+	sibling := big.NewInt(10)
+
+	for _, tc := range []struct {
+		name   string
+		txType int
+		fee    gas.EvmFee
+	}{
+		{"legacy", 0x0, gas.EvmFee{Legacy: assets.GWei(25)}},
+		{"dynamic", 0x2, gas.EvmFee{DynamicFeeCap: assets.GWei(50), DynamicTipCap: assets.GWei(2)}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			a, _, err := cks.NewCustomTxAttemptForChain(etx, tc.fee, 21000, tc.txType, sibling, lggr)
+			require.NoError(t, err)
+			tx, err := txmgr.GetGethSignedTx(a.SignedRawTx)
+			require.NoError(t, err)
+			assert.Equal(t, sibling, tx.ChainId())
+			from, err := types.Sender(types.LatestSignerForChainID(sibling), tx)
+			require.NoError(t, err)
+			assert.Equal(t, addr, from)
+
+			a, _, err = cks.NewCustomTxAttemptForChain(etx, tc.fee, 21000, tc.txType, nil, lggr)
+			require.NoError(t, err)
+			tx, err = txmgr.GetGethSignedTx(a.SignedRawTx)
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(1), tx.ChainId())
+		})
+	}
+
+	t.Run("SignTxForChain", func(t *testing.T) {
+		hash, raw, err := cks.SignTxForChain(addr, types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000}), sibling)
+		require.NoError(t, err)
+		tx, err := txmgr.GetGethSignedTx(raw)
+		require.NoError(t, err)
+		assert.Equal(t, hash, tx.Hash())
+		assert.Equal(t, sibling, tx.ChainId())
+	})
+
+	t.Run("rejects a zero chain ID", func(t *testing.T) {
+		_, _, err := cks.SignTxForChain(addr, types.NewTx(&types.LegacyTx{}), big.NewInt(0))
+		require.ErrorContains(t, err, "chain ID must be non-zero")
+		_, retryable, err := cks.NewCustomTxAttemptForChain(etx, gas.EvmFee{Legacy: assets.GWei(25)}, 21000, 0x0, big.NewInt(0), lggr)
+		require.ErrorContains(t, err, "chain ID must be non-zero")
+		assert.False(t, retryable)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_UnderpricedRetry(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	previous := txmgr.TxAttempt{TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.GWei(10)}}
+	underpriced := errors.New("replacement transaction underpriced")
+
+	newBuilder := func(t *testing.T, priceMax *assets.Wei, broadcast txmgr.BumpBroadcastFunc, maxRetries int) txmgr.TxAttemptBuilder {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, previous.TxFee, mock.Anything, mock.Anything, mock.Anything).Return(gas.EvmFee{Legacy: assets.GWei(11)}, uint32(100), nil).Once()
+		gc := newFeeConfig()
+		gc.priceMax = priceMax
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+		cks.SetBumpBroadcast(broadcast, maxRetries)
+		return cks
+	}
+
+	t.Run("doubles the bump until the attempt is accepted", func(t *testing.T) {
+		var broadcast []*assets.Wei
+		cks := newBuilder(t, assets.GWei(100), func(ctx context.Context, a txmgr.TxAttempt) error {
+			broadcast = append(broadcast, a.TxFee.Legacy)
+			if len(broadcast) <= 2 {
+				return underpriced
+			}
+			return nil
+		}, 3)
+
+		a, fee, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, []*assets.Wei{assets.GWei(11), assets.GWei(12), assets.GWei(14)}, broadcast)
+		assert.Equal(t, assets.GWei(14), fee.Legacy)
+		assert.Equal(t, assets.GWei(14), a.TxFee.Legacy)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		calls := 0
+		cks := newBuilder(t, assets.GWei(100), func(ctx context.Context, a txmgr.TxAttempt) error {
+			calls++
+			return underpriced
+		}, 1)
+
+		_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.ErrorContains(t, err, "bumped attempt still underpriced after 1 retries")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("caps the bump at the max gas price for the key", func(t *testing.T) {
+		var broadcast []*assets.Wei
+		cks := newBuilder(t, assets.GWei(12), func(ctx context.Context, a txmgr.TxAttempt) error {
+			broadcast = append(broadcast, a.TxFee.Legacy)
+			return underpriced
+		}, 5)
+
+		_, _, _, retryable, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+		require.ErrorIs(t, err, commonfee.ErrBumpFeeExceedsLimit)
+		assert.False(t, retryable)
+		assert.Equal(t, []*assets.Wei{assets.GWei(11), assets.GWei(12)}, broadcast)
+	})
+
+	t.Run("surfaces other broadcast errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			err       string
+			retryable bool
+		}{
+			{"connection refused", true},
+			{"nonce too low", false},
+			{"insufficient funds for gas * price + value", false},
+			{"invalid sender", false},
+		} {
+			t.Run(tc.err, func(t *testing.T) {
+				cks := newBuilder(t, assets.GWei(100), func(ctx context.Context, a txmgr.TxAttempt) error {
+					return errors.New(tc.err)
+				}, 3)
+
+				_, _, _, retryable, err := cks.NewBumpTxAttempt(ctx, etx, previous, nil, lggr)
+				require.ErrorContains(t, err, "failed to broadcast bumped attempt: "+tc.err)
+				assert.Equal(t, tc.retryable, retryable)
+			})
+		}
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewCancelAttempt(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	lggr := logger.TestLogger(t)
+	n := evmtypes.Nonce(7)
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr, ToAddress: NewEvmAddress(), Value: *big.NewInt(100), EncodedPayload: []byte{1, 2, 3}}
+
+	isCancel := func(txType uint8) func(tx *types.Transaction) bool {
+		return func(tx *types.Transaction) bool {
+			return tx.Type() == txType && *tx.To() == addr && tx.Value().Sign() == 0 && len(tx.Data()) == 0 && tx.Nonce() == 7
+		}
+	}
+
+	t.Run("legacy", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(isCancel(0x0)), big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil).Once()
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(100)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+		a, _, err := cks.NewCancelAttempt(etx, gas.NewLegacyFee(assets.GWei(20)), 21000, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x0, a.TxType)
+		assert.Equal(t, addr, a.Tx.ToAddress)
+		assert.Equal(t, big.NewInt(0), a.Tx.ValueBigInt())
+		assert.Empty(t, a.Tx.EncodedPayload)
+	})
+
+	t.Run("dynamic when EIP-1559 is enabled", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(isCancel(0x2)), big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+		gc := newFeeConfig()
+		gc.eip1559DynamicFees = true
+		gc.priceMax = assets.GWei(100)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+		a, _, err := cks.NewCancelAttempt(etx, gas.NewDynamicFee(assets.GWei(20), assets.GWei(2)), 21000, lggr)
+		require.NoError(t, err)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, addr, a.Tx.ToAddress)
+	})
+
+	t.Run("validates the fee", func(t *testing.T) {
+		gc := newFeeConfig()
+		gc.priceMax = assets.GWei(10)
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+
+		_, _, err := cks.NewCancelAttempt(etx, gas.NewLegacyFee(assets.GWei(20)), 21000, lggr)
+		require.ErrorIs(t, err, txmgr.ErrFeeExceedsMax)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_ConvertLegacyToDynamic(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	n := evmtypes.Nonce(7)
+	etx := txmgr.Tx{ID: 1, Sequence: &n, FromAddress: addr, ToAddress: NewEvmAddress()}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(100)
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, nil)
+	legacy := txmgr.TxAttempt{ID: 42, Tx: etx, TxType: 0x0, TxFee: gas.NewLegacyFee(assets.GWei(20)), ChainSpecificFeeLimit: 21000}
+
+	t.Run("re-signs a legacy attempt as dynamic", func(t *testing.T) {
+		kst.On("SignTx", addr, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Type() == 0x2 && tx.Nonce() == 7 && tx.Gas() == 21000 &&
+				tx.GasFeeCap().Cmp(assets.GWei(20).ToInt()) == 0 && tx.GasTipCap().Cmp(assets.GWei(2).ToInt()) == 0
+		}), big.NewInt(1)).Return(types.NewTx(&types.DynamicFeeTx{}), nil).Once()
+
+		a, err := cks.ConvertLegacyToDynamic(legacy, assets.GWei(2))
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), a.ID)
+		assert.Equal(t, 0x2, a.TxType)
+		assert.Equal(t, gas.NewDynamicFee(assets.GWei(20), assets.GWei(2)), a.TxFee)
+		assert.Equal(t, uint32(21000), a.ChainSpecificFeeLimit)
+	})
+	t.Run("rejects an attempt that is not legacy", func(t *testing.T) {
+		dynamic := txmgr.TxAttempt{ID: 43, Tx: etx, TxType: 0x2, TxFee: gas.NewDynamicFee(assets.GWei(20), assets.GWei(2)), ChainSpecificFeeLimit: 21000}
+		_, err := cks.ConvertLegacyToDynamic(dynamic, assets.GWei(2))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot convert attempt 43 to dynamic fees: expected a legacy attempt with a gas price, got tx type 0x2")
+	})
+	t.Run("validates the converted fee", func(t *testing.T) {
+		_, err := cks.ConvertLegacyToDynamic(legacy, assets.GWei(30))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "gas fee cap must be greater than or equal to gas tip cap")
+	})
+	t.Run("rejects a payload over the max", func(t *testing.T) {
+		oversized := legacy
+		oversized.Tx.EncodedPayload = []byte{1, 2, 3}
+		gc.maxPayloadBytes = 2
+		t.Cleanup(func() { gc.maxPayloadBytes = 0 })
+		_, err := cks.ConvertLegacyToDynamic(oversized, assets.GWei(2))
+		require.ErrorIs(t, err, txmgr.ErrPayloadTooLarge)
+	})
+}
+
+func TestTxm_EvmTxAttemptBuilder_SupportsEIP1559(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	gc := newFeeConfig()
+	gc.eip1559DynamicFees = true
+
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("SupportsEIP1559").Return(false).Once()
+	est.On("Name").Return("FixedPrice").Once()
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, ksmocks.NewEth(t), est)
+
+	_, _, _, retryable, err := cks.NewTxAttempt(testutils.Context(t), txmgr.Tx{}, lggr)
+	require.ErrorIs(t, err, txmgr.ErrEIP1559NotSupported)
+	assert.Contains(t, err.Error(), "EIP-1559 dynamic fees are enabled but the FixedPrice estimator does not support them")
+	assert.False(t, retryable)
+}
+
+func TestTxm_EvmTxAttemptBuilder_NewBumpTxAttempt_MaxPriorAttempts(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{Sequence: &n, FromAddress: addr}
+	gc := newFeeConfig()
+	gc.priceMax = assets.GWei(1)
+
+	// ordered by fee as loaded from the tx store, not by recency
+	priorAttempts := []txmgr.TxAttempt{
+		{ID: 4, Hash: testutils.NewHash(), TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(40)}},
+		{ID: 1, Hash: testutils.NewHash(), TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(35)}},
+		{ID: 3, Hash: testutils.NewHash(), TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(30)}},
+		{ID: 2, Hash: testutils.NewHash(), TxType: 0x0, TxFee: gas.EvmFee{Legacy: assets.NewWeiI(20)}},
+	}
+	bump := func(t *testing.T, maxPriorAttempts uint32) (received []gas.EvmPriorAttempt) {
+		est := gasmocks.NewEvmFeeEstimator(t)
+		est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { received = args.Get(4).([]gas.EvmPriorAttempt) }).
+			Return(gas.EvmFee{Legacy: assets.NewWeiI(50)}, uint32(100), nil).Once()
+		gc.maxPriorAttempts = maxPriorAttempts
+		cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+
+		_, _, _, _, err := cks.NewBumpTxAttempt(testutils.Context(t), etx, priorAttempts[0], priorAttempts, lggr)
+		require.NoError(t, err)
+		return received
+	}
+
+	t.Run("passes the most recent attempts in their original order", func(t *testing.T) {
+		received := bump(t, 2)
+		require.Len(t, received, 2)
+		assert.Equal(t, priorAttempts[0].Hash, received[0].TxHash)
+		assert.Equal(t, priorAttempts[2].Hash, received[1].TxHash)
+	})
+
+	t.Run("zero means unlimited", func(t *testing.T) {
+		assert.Len(t, bump(t, 0), 4)
+	})
+}
+
+type recordingFeeDecisionObserver struct {
+	decisions []txmgr.FeeDecision
+}
+
+func (o *recordingFeeDecisionObserver) ObserveFee(decision txmgr.FeeDecision) {
+	o.decisions = append(o.decisions, decision)
+}
+
+func TestTxm_EvmTxAttemptBuilder_FeeDecisionObserver(t *testing.T) {
+	addr := NewEvmAddress()
+	kst := ksmocks.NewEth(t)
+	kst.On("SignTx", addr, mock.Anything, big.NewInt(1)).Return(types.NewTx(&types.LegacyTx{}), nil)
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+	gc := newFeeConfig()
+	gc.priceMax = assets.NewWeiI(50)
+	var n evmtypes.Nonce
+	etx := txmgr.Tx{ID: 7, Sequence: &n, FromAddress: addr, FeeLimit: 100}
+
+	est := gasmocks.NewEvmFeeEstimator(t)
+	est.On("GetFeeForType", mock.Anything, 0x0, mock.Anything, mock.Anything, uint32(100), mock.Anything).Return(gas.NewLegacyFee(assets.NewWeiI(40)), uint32(110), nil).Once()
+	est.On("BumpFee", mock.Anything, gas.NewLegacyFee(assets.NewWeiI(40)), uint32(100), mock.Anything, mock.Anything).Return(gas.NewLegacyFee(assets.NewWeiI(50)), uint32(120), nil).Once()
+	cks := txmgr.NewEvmTxAttemptBuilder(*big.NewInt(1), gc, kst, est)
+	observer := &recordingFeeDecisionObserver{}
+	cks.SetFeeDecisionObserver(observer)
+
+	a, _, _, _, err := cks.NewTxAttempt(ctx, etx, lggr)
+	require.NoError(t, err)
+	_, _, _, _, err = cks.NewBumpTxAttempt(ctx, etx, a, nil, lggr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []txmgr.FeeDecision{
+		{TxID: 7, TxType: 0x0, RequestedFeeLimit: 100, Fee: gas.NewLegacyFee(assets.NewWeiI(40)), FeeLimit: 110},
+		{TxID: 7, TxType: 0x0, Bump: true, PreviousFee: gas.NewLegacyFee(assets.NewWeiI(40)), RequestedFeeLimit: 100, Fee: gas.NewLegacyFee(assets.NewWeiI(50)), FeeLimit: 120, FeeCapped: true},
+	}, observer.decisions)
+
+	t.Run("nil observer", func(t *testing.T) {
+		est.On("BumpFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gas.NewLegacyFee(assets.NewWeiI(50)), uint32(120), nil).Once()
+		cks.SetFeeDecisionObserver(nil)
+		_, _, _, _, err := cks.NewBumpTxAttempt(ctx, etx, a, nil, lggr)
+		require.NoError(t, err)
+		assert.Len(t, observer.decisions, 2)
+	})
+}
@@ -44,6 +44,11 @@ func NewTxm(
 	checker := &CheckerFactory{Client: client}
 	// create tx attempt builder
 	txAttemptBuilder := NewEvmTxAttemptBuilder(*client.ConfiguredChainID(), fCfg, keyStore, estimator)
+	txAttemptBuilder.SetSignatureScheme(SignatureSchemeForChainType(chainConfig.ChainType()))
+	if fCfg.BumpTipOnly() && enforcesGethReplacementRule(chainConfig.ChainType()) {
+		lggr.Warnw("GasEstimator.BumpTipOnly is enabled, but this chain's nodes reject replacements that do not also raise the fee cap by at least 10%, so tip-only bumps are likely to fail as replacement underpriced",
+			"chainType", chainConfig.ChainType())
+	}
 	txStore := NewTxStore(db, lggr, dbConfig)
 	txNonceSyncer := NewNonceSyncer(txStore, lggr, client)
 
@@ -24,15 +24,31 @@ type ChainConfig interface {
 
 type FeeConfig interface {
 	EIP1559DynamicFees() bool
+	BumpMin() *assets.Wei
 	BumpPercent() uint16
 	BumpThreshold() uint64
 	BumpTxDepth() uint32
 	LimitDefault() uint32
 	PriceDefault() *assets.Wei
+	TipCapDefault() *assets.Wei
 	TipCapMin() *assets.Wei
 	PriceMax() *assets.Wei
 	PriceMin() *assets.Wei
 	PriceMaxKey(gethcommon.Address) *assets.Wei
+	MinBumpPercent() uint16
+	TipOnlyDynamicFees() bool
+	TipOnlyBaseFeeMultiplier() uint16
+	MandatoryBaseFee() *assets.Wei
+	MaxPayloadBytes() uint32
+	UpgradeLegacyOnBump() bool
+	BumpTipOnly() bool
+	MaxPriorAttempts() uint32
+	MinFeeCapTipMarginWei() *assets.Wei
+	BumpGasLimitPercent() uint16
+	BumpGasLimitMax() uint32
+	MaxTotalCostWei() *assets.Wei
+	BumpJitterBasisPoints() uint16
+	StripAccessListOnBump() bool
 }
 
 type DatabaseConfig interface {
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	pkgerrors "github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/common/txmgr"
 	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
@@ -71,3 +72,13 @@ func GetGethSignedTx(signedRawTx []byte) (*types.Transaction, error) {
 	}
 	return signedTx, nil
 }
+
+// HashFromSignedRawTx decodes the SignedRawTx and returns its tx hash and tx type, without needing the keystore.
+// Used to recompute and verify the Hash of persisted attempts.
+func HashFromSignedRawTx(signedRawTx []byte) (common.Hash, int, error) {
+	signedTx, err := GetGethSignedTx(signedRawTx)
+	if err != nil {
+		return common.Hash{}, 0, pkgerrors.Wrap(err, "failed to decode signed raw tx")
+	}
+	return signedTx.Hash(), int(signedTx.Type()), nil
+}
@@ -1,10 +1,15 @@
 package txmgr
 
 import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	commonconfig "github.com/smartcontractkit/chainlink/v2/common/config"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
@@ -16,6 +21,26 @@ import (
 
 func ptr[T any](t T) *T { return &t }
 
+type testDeterministicSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewTestDeterministicSigner returns an in-process signer for tests. ECDSA signatures are derived per RFC 6979, so the
+// same tx is always signed to the same bytes.
+func NewTestDeterministicSigner(key *ecdsa.PrivateKey) DeterministicTxAttemptSigner {
+	return &testDeterministicSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *testDeterministicSigner) SignTx(fromAddress common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if fromAddress != s.address {
+		return nil, fmt.Errorf("test signer has no key for %s", fromAddress)
+	}
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+func (s *testDeterministicSigner) Deterministic() bool { return true }
+
 type TestDatabaseConfig struct {
 	config.Database
 	defaultQueryTimeout time.Duration
@@ -67,22 +92,37 @@ func (g *TestGasEstimatorConfig) BlockHistory() evmconfig.BlockHistory {
 	return &TestBlockHistoryConfig{}
 }
 
-func (g *TestGasEstimatorConfig) EIP1559DynamicFees() bool   { return false }
-func (g *TestGasEstimatorConfig) LimitDefault() uint32       { return 42 }
-func (g *TestGasEstimatorConfig) BumpPercent() uint16        { return 42 }
-func (g *TestGasEstimatorConfig) BumpThreshold() uint64      { return g.bumpThreshold }
-func (g *TestGasEstimatorConfig) BumpMin() *assets.Wei       { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) FeeCapDefault() *assets.Wei { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) PriceDefault() *assets.Wei  { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) TipCapDefault() *assets.Wei { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) TipCapMin() *assets.Wei     { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) LimitMax() uint32           { return 0 }
-func (g *TestGasEstimatorConfig) LimitMultiplier() float32   { return 0 }
-func (g *TestGasEstimatorConfig) BumpTxDepth() uint32        { return 42 }
-func (g *TestGasEstimatorConfig) LimitTransfer() uint32      { return 42 }
-func (g *TestGasEstimatorConfig) PriceMax() *assets.Wei      { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) PriceMin() *assets.Wei      { return assets.NewWeiI(42) }
-func (g *TestGasEstimatorConfig) Mode() string               { return "FixedPrice" }
+func (g *TestGasEstimatorConfig) EIP1559DynamicFees() bool           { return false }
+func (g *TestGasEstimatorConfig) LimitDefault() uint32               { return 42 }
+func (g *TestGasEstimatorConfig) BumpPercent() uint16                { return 42 }
+func (g *TestGasEstimatorConfig) BumpThreshold() uint64              { return g.bumpThreshold }
+func (g *TestGasEstimatorConfig) BumpMin() *assets.Wei               { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) FeeCapDefault() *assets.Wei         { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) PriceDefault() *assets.Wei          { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) TipCapDefault() *assets.Wei         { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) TipCapMin() *assets.Wei             { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) LimitMax() uint32                   { return 0 }
+func (g *TestGasEstimatorConfig) LimitMultiplier() float32           { return 0 }
+func (g *TestGasEstimatorConfig) BumpTxDepth() uint32                { return 42 }
+func (g *TestGasEstimatorConfig) LimitTransfer() uint32              { return 42 }
+func (g *TestGasEstimatorConfig) PriceMax() *assets.Wei              { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) PriceMin() *assets.Wei              { return assets.NewWeiI(42) }
+func (g *TestGasEstimatorConfig) Mode() string                       { return "FixedPrice" }
+func (g *TestGasEstimatorConfig) StaleFeeGracePeriod() time.Duration { return 0 }
+func (g *TestGasEstimatorConfig) StripAccessListOnBump() bool        { return false }
+func (g *TestGasEstimatorConfig) BumpJitterBasisPoints() uint16      { return 0 }
+func (g *TestGasEstimatorConfig) MaxTotalCostWei() *assets.Wei       { return nil }
+func (g *TestGasEstimatorConfig) BumpGasLimitMax() uint32            { return 0 }
+func (g *TestGasEstimatorConfig) BumpGasLimitPercent() uint16        { return 0 }
+func (g *TestGasEstimatorConfig) MinFeeCapTipMarginWei() *assets.Wei { return nil }
+func (g *TestGasEstimatorConfig) MaxPriorAttempts() uint32           { return 0 }
+func (g *TestGasEstimatorConfig) BumpTipOnly() bool                  { return false }
+func (g *TestGasEstimatorConfig) UpgradeLegacyOnBump() bool          { return false }
+func (g *TestGasEstimatorConfig) MaxPayloadBytes() uint32            { return 0 }
+func (g *TestGasEstimatorConfig) MandatoryBaseFee() *assets.Wei      { return nil }
+func (g *TestGasEstimatorConfig) TipOnlyBaseFeeMultiplier() uint16   { return 0 }
+func (g *TestGasEstimatorConfig) TipOnlyDynamicFees() bool           { return false }
+func (g *TestGasEstimatorConfig) MinBumpPercent() uint16             { return 0 }
 func (g *TestGasEstimatorConfig) LimitJobType() evmconfig.LimitJobType {
 	return &TestLimitJobTypeConfig{}
 }
@@ -84,6 +84,24 @@ func (h *Head) BlockDifficulty() *utils.Big {
 	return h.Difficulty
 }
 
+// ChainTotalDifficulty returns the sum of block difficulties across the chain followed by recursively looking up parents.
+// Not to be confused with the TotalDifficulty reported by the RPC, which covers the whole chain since genesis.
+func (h *Head) ChainTotalDifficulty() *utils.Big {
+	total := utils.NewBigI(0)
+	for cur := h; cur != nil; cur = cur.Parent {
+		if cur.Difficulty != nil {
+			total = total.Add(cur.Difficulty)
+		}
+	}
+	return total
+}
+
+// IsFinalizedAt returns true if the head is at least finalityDepth blocks
+// behind the given latest block number
+func (h *Head) IsFinalizedAt(latestBlockNumber int64, finalityDepth uint32) bool {
+	return latestBlockNumber-h.Number >= int64(finalityDepth)
+}
+
 // EarliestInChain recurses through parents until it finds the earliest one
 func (h *Head) EarliestInChain() *Head {
 	for h.Parent != nil {
@@ -112,6 +130,28 @@ func (h *Head) IsInChain(blockHash common.Hash) bool {
 	return false
 }
 
+// HeadNearestTimestamp traverses through parents and returns the head whose timestamp is closest to t,
+// preferring the more recent head on a tie, or nil if the chain is empty
+func (h *Head) HeadNearestTimestamp(t time.Time) commontypes.Head[common.Hash] {
+	if h == nil {
+		return nil
+	}
+	nearest, nearestDiff := h, absDuration(t.Sub(h.Timestamp))
+	for cur := h.Parent; cur != nil; cur = cur.Parent {
+		if diff := absDuration(t.Sub(cur.Timestamp)); diff < nearestDiff {
+			nearest, nearestDiff = cur, diff
+		}
+	}
+	return nearest
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 // HashAtHeight returns the hash of the block at the given height, if it is in the chain.
 // If not in chain, returns the zero hash
 func (h *Head) HashAtHeight(blockNum int64) common.Hash {
@@ -273,6 +273,53 @@ func TestHead_IsInChain(t *testing.T) {
 	assert.False(t, head.IsInChain(common.Hash{}))
 }
 
+func TestHead_HeadNearestTimestamp(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	h1 := &evmtypes.Head{Number: 1, Timestamp: start}
+	h2 := &evmtypes.Head{Number: 2, Timestamp: start.Add(12 * time.Second), Parent: h1}
+	h3 := &evmtypes.Head{Number: 3, Timestamp: start.Add(24 * time.Second), Parent: h2}
+
+	assert.Equal(t, int64(1), h3.HeadNearestTimestamp(start.Add(-time.Minute)).BlockNumber())
+	assert.Equal(t, int64(2), h3.HeadNearestTimestamp(start.Add(10*time.Second)).BlockNumber())
+	assert.Equal(t, int64(3), h3.HeadNearestTimestamp(start.Add(time.Minute)).BlockNumber())
+
+	var nilHead *evmtypes.Head
+	assert.Nil(t, nilHead.HeadNearestTimestamp(start))
+}
+
+func TestHead_IsFinalizedAt(t *testing.T) {
+	head := evmtypes.Head{Number: 100}
+
+	t.Run("exactly at finality depth", func(t *testing.T) {
+		assert.True(t, head.IsFinalizedAt(110, 10))
+	})
+	t.Run("one block below finality depth", func(t *testing.T) {
+		assert.False(t, head.IsFinalizedAt(109, 10))
+	})
+	t.Run("one block above finality depth", func(t *testing.T) {
+		assert.True(t, head.IsFinalizedAt(111, 10))
+	})
+	t.Run("zero finality depth", func(t *testing.T) {
+		assert.True(t, head.IsFinalizedAt(100, 0))
+	})
+	t.Run("latest block behind head", func(t *testing.T) {
+		assert.False(t, head.IsFinalizedAt(99, 0))
+	})
+}
+
+func TestHead_ChainTotalDifficulty(t *testing.T) {
+	h1 := &evmtypes.Head{Number: 1, Difficulty: utils.NewBigI(3)}
+	h2 := &evmtypes.Head{Number: 2, Difficulty: utils.NewBigI(5), Parent: h1}
+	h3 := &evmtypes.Head{Number: 3, Difficulty: utils.NewBigI(7), Parent: h2}
+
+	assert.Equal(t, int64(15), h3.ChainTotalDifficulty().Int64())
+	assert.Equal(t, int64(8), h2.ChainTotalDifficulty().Int64())
+	assert.Equal(t, int64(3), h1.ChainTotalDifficulty().Int64())
+
+	noDifficulty := &evmtypes.Head{Number: 4, Parent: &evmtypes.Head{Number: 3}}
+	assert.Equal(t, int64(0), noDifficulty.ChainTotalDifficulty().Int64())
+}
+
 func TestTxReceipt_ReceiptIndicatesRunLogFulfillment(t *testing.T) {
 	tests := []struct {
 		name string
@@ -67,6 +67,51 @@ func TestDoc(t *testing.T) {
 		require.Zero(t, *docDefaults.GasEstimator.BumpTxDepth)
 		docDefaults.GasEstimator.BumpTxDepth = nil
 
+		require.Zero(t, *docDefaults.GasEstimator.MinBumpPercent)
+		docDefaults.GasEstimator.MinBumpPercent = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.TipOnlyDynamicFees)
+		docDefaults.GasEstimator.TipOnlyDynamicFees = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.TipOnlyBaseFeeMultiplier)
+		docDefaults.GasEstimator.TipOnlyBaseFeeMultiplier = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.MandatoryBaseFee)
+		docDefaults.GasEstimator.MandatoryBaseFee = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.MaxPayloadBytes)
+		docDefaults.GasEstimator.MaxPayloadBytes = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.UpgradeLegacyOnBump)
+		docDefaults.GasEstimator.UpgradeLegacyOnBump = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.BumpTipOnly)
+		docDefaults.GasEstimator.BumpTipOnly = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.MaxPriorAttempts)
+		docDefaults.GasEstimator.MaxPriorAttempts = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.MinFeeCapTipMargin)
+		docDefaults.GasEstimator.MinFeeCapTipMargin = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.BumpGasLimitPercent)
+		docDefaults.GasEstimator.BumpGasLimitPercent = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.BumpGasLimitMax)
+		docDefaults.GasEstimator.BumpGasLimitMax = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.MaxTotalCost)
+		docDefaults.GasEstimator.MaxTotalCost = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.BumpJitterBasisPoints)
+		docDefaults.GasEstimator.BumpJitterBasisPoints = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.StripAccessListOnBump)
+		docDefaults.GasEstimator.StripAccessListOnBump = nil
+
+		require.Zero(t, *docDefaults.GasEstimator.StaleFeeGracePeriod)
+		docDefaults.GasEstimator.StaleFeeGracePeriod = nil
+
 		// per-job limits are nilable
 		require.Zero(t, *docDefaults.GasEstimator.LimitJobType.OCR)
 		require.Zero(t, *docDefaults.GasEstimator.LimitJobType.OCR2)
@@ -80,6 +125,9 @@ func TestDoc(t *testing.T) {
 		require.Zero(t, *docDefaults.GasEstimator.BlockHistory.EIP1559FeeCapBufferBlocks)
 		docDefaults.GasEstimator.BlockHistory.EIP1559FeeCapBufferBlocks = nil
 
+		require.Zero(t, *docDefaults.GasEstimator.BlockHistory.FeeSpikeThresholdPercent)
+		docDefaults.GasEstimator.BlockHistory.FeeSpikeThresholdPercent = nil
+
 		// addresses w/o global values
 		require.Zero(t, *docDefaults.FlagsContractAddress)
 		require.Zero(t, *docDefaults.LinkContractAddress)
@@ -489,22 +489,37 @@ func TestConfig_Marshal(t *testing.T) {
 				FlagsContractAddress: mustAddress("0xae4E781a6218A8031764928E88d457937A954fC3"),
 
 				GasEstimator: evmcfg.GasEstimator{
-					Mode:               ptr("SuggestedPrice"),
-					EIP1559DynamicFees: ptr(true),
-					BumpPercent:        ptr[uint16](10),
-					BumpThreshold:      ptr[uint32](6),
-					BumpTxDepth:        ptr[uint32](6),
-					BumpMin:            assets.NewWeiI(100),
-					FeeCapDefault:      assets.NewWeiI(math.MaxInt64),
-					LimitDefault:       ptr[uint32](12),
-					LimitMax:           ptr[uint32](17),
-					LimitMultiplier:    mustDecimal("1.234"),
-					LimitTransfer:      ptr[uint32](100),
-					TipCapDefault:      assets.NewWeiI(2),
-					TipCapMin:          assets.NewWeiI(1),
-					PriceDefault:       assets.NewWeiI(math.MaxInt64),
-					PriceMax:           assets.NewWei(utils.HexToBig("FFFFFFFFFFFF")),
-					PriceMin:           assets.NewWeiI(13),
+					Mode:                     ptr("SuggestedPrice"),
+					EIP1559DynamicFees:       ptr(true),
+					BumpPercent:              ptr[uint16](10),
+					BumpThreshold:            ptr[uint32](6),
+					BumpTxDepth:              ptr[uint32](6),
+					BumpMin:                  assets.NewWeiI(100),
+					FeeCapDefault:            assets.NewWeiI(math.MaxInt64),
+					LimitDefault:             ptr[uint32](12),
+					LimitMax:                 ptr[uint32](17),
+					LimitMultiplier:          mustDecimal("1.234"),
+					LimitTransfer:            ptr[uint32](100),
+					TipCapDefault:            assets.NewWeiI(2),
+					TipCapMin:                assets.NewWeiI(1),
+					PriceDefault:             assets.NewWeiI(math.MaxInt64),
+					PriceMax:                 assets.NewWei(utils.HexToBig("FFFFFFFFFFFF")),
+					PriceMin:                 assets.NewWeiI(13),
+					TipOnlyBaseFeeMultiplier: ptr[uint16](3),
+					TipOnlyDynamicFees:       ptr(true),
+					MinBumpPercent:           ptr[uint16](15),
+					MandatoryBaseFee:         assets.GWei(10),
+					MaxPayloadBytes:          ptr[uint32](131072),
+					UpgradeLegacyOnBump:      ptr(true),
+					BumpTipOnly:              ptr(true),
+					MaxPriorAttempts:         ptr[uint32](10),
+					MinFeeCapTipMargin:       assets.GWei(1),
+					BumpGasLimitPercent:      ptr[uint16](10),
+					BumpGasLimitMax:          ptr[uint32](1000000),
+					MaxTotalCost:             assets.NewWeiI(1e18),
+					BumpJitterBasisPoints:    ptr[uint16](50),
+					StripAccessListOnBump:    ptr(true),
+					StaleFeeGracePeriod:      models.MustNewDuration(time.Minute),
 
 					LimitJobType: evmcfg.GasLimitJobType{
 						OCR:    ptr[uint32](1001),
@@ -522,6 +537,7 @@ func TestConfig_Marshal(t *testing.T) {
 						CheckInclusionPercentile:  ptr[uint16](19),
 						EIP1559FeeCapBufferBlocks: ptr[uint16](13),
 						TransactionPercentile:     ptr[uint16](15),
+						FeeSpikeThresholdPercent:  ptr[uint16](50),
 					},
 				},
 
@@ -969,6 +985,21 @@ EIP1559DynamicFees = true
 FeeCapDefault = '9.223372036854775807 ether'
 TipCapDefault = '2 wei'
 TipCapMin = '1 wei'
+MinBumpPercent = 15
+TipOnlyDynamicFees = true
+TipOnlyBaseFeeMultiplier = 3
+MandatoryBaseFee = '10 gwei'
+MaxPayloadBytes = 131072
+UpgradeLegacyOnBump = true
+BumpTipOnly = true
+MaxPriorAttempts = 10
+MinFeeCapTipMargin = '1 gwei'
+BumpGasLimitPercent = 10
+BumpGasLimitMax = 1000000
+MaxTotalCost = '1 ether'
+BumpJitterBasisPoints = 50
+StripAccessListOnBump = true
+StaleFeeGracePeriod = '1m0s'
 
 [EVM.GasEstimator.LimitJobType]
 OCR = 1001
@@ -985,6 +1016,7 @@ CheckInclusionBlocks = 18
 CheckInclusionPercentile = 19
 EIP1559FeeCapBufferBlocks = 13
 TransactionPercentile = 15
+FeeSpikeThresholdPercent = 50
 
 [EVM.HeadTracker]
 HistoryDepth = 15
@@ -1176,12 +1208,13 @@ func TestConfig_Validate(t *testing.T) {
 		- 3.Nodes.4.WSURL: invalid value (ws://dupe.com): duplicate - must be unique
 		- 0: 3 errors:
 			- GasEstimator.BumpTxDepth: invalid value (11): must be less than or equal to Transactions.MaxInFlight
-			- GasEstimator: 6 errors:
+			- GasEstimator: 7 errors:
 				- BumpPercent: invalid value (1): may not be less than Geth's default of 10
 				- TipCapDefault: invalid value (3 wei): must be greater than or equal to TipCapMinimum
 				- FeeCapDefault: invalid value (3 wei): must be greater than or equal to TipCapDefault
 				- PriceMin: invalid value (10 gwei): must be less than or equal to PriceDefault
 				- PriceMax: invalid value (10 gwei): must be greater than or equal to PriceDefault
+				- BumpJitterBasisPoints: invalid value (10001): must be less than or equal to 1000
 				- BlockHistory.BlockHistorySize: invalid value (0): must be greater than or equal to 1 with BlockHistory Mode
 			- Nodes: 2 errors:
 				- 0: 2 errors:
@@ -4,12 +4,17 @@
 package config
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
+	"slices"
+	"strings"
 
 	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/exp/maps"
 
 	"github.com/smartcontractkit/chainlink/v2/core/null"
 	mercuryutils "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/utils"
@@ -19,6 +24,9 @@ import (
 type PluginConfig struct {
 	RawServerURL string              `json:"serverURL" toml:"serverURL"`
 	ServerPubKey utils.PlainHexBytes `json:"serverPubKey" toml:"serverPubKey"`
+	// Servers maps server URLs to public keys; not yet supported by the relay
+	Servers map[string]utils.PlainHexBytes `json:"servers" toml:"servers"`
+
 	// InitialBlockNumber allows to set a custom "validFromBlockNumber" for
 	// the first ever report in the case of a brand new feed, where the mercury
 	// server does not have any previous reports. For a brand new feed, this
@@ -29,27 +37,18 @@ type PluginConfig struct {
 	NativeFeedID *mercuryutils.FeedID `json:"nativeFeedID" toml:"nativeFeedID"`
 }
 
-func ValidatePluginConfig(config PluginConfig, feedID mercuryutils.FeedID) (merr error) {
-	if config.RawServerURL == "" {
-		merr = errors.New("mercury: ServerURL must be specified")
-	} else {
-		var normalizedURI string
-		if schemeRegexp.MatchString(config.RawServerURL) {
-			normalizedURI = config.RawServerURL
-		} else {
-			normalizedURI = fmt.Sprintf("wss://%s", config.RawServerURL)
-		}
-		uri, err := url.ParseRequestURI(normalizedURI)
-		if err != nil {
-			merr = pkgerrors.Wrap(err, "Mercury: invalid value for ServerURL")
-		} else if uri.Scheme != "wss" {
-			merr = pkgerrors.Errorf(`Mercury: invalid scheme specified for MercuryServer, got: %q (scheme: %q) but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"`, config.RawServerURL, uri.Scheme)
-		}
+// NewPluginConfig returns a config for a single server, failing fast if the server URL or public key is invalid.
+// Checks that depend on the feed, e.g. the link and native feed IDs, are left to ValidatePluginConfig.
+func NewPluginConfig(rawURL string, pubKey []byte) (PluginConfig, error) {
+	config := PluginConfig{RawServerURL: rawURL, ServerPubKey: pubKey}
+	if err := validateServers(config); err != nil {
+		return PluginConfig{}, err
 	}
+	return config, nil
+}
 
-	if len(config.ServerPubKey) != 32 {
-		merr = errors.Join(merr, errors.New("mercury: ServerPubKey is required and must be a 32-byte hex string"))
-	}
+func ValidatePluginConfig(config PluginConfig, feedID mercuryutils.FeedID) (merr error) {
+	merr = validateServers(config)
 
 	switch feedID.Version() {
 	case 1:
@@ -76,9 +75,161 @@ func ValidatePluginConfig(config PluginConfig, feedID mercuryutils.FeedID) (merr
 	return merr
 }
 
+// validateServers checks that exactly one of the single server fields or Servers is set, and that it is valid
+func validateServers(config PluginConfig) (merr error) {
+	hasSingle := config.RawServerURL != "" || len(config.ServerPubKey) != 0
+	hasMulti := len(config.Servers) > 0
+
+	switch {
+	case hasSingle && hasMulti:
+		merr = errors.New("mercury: ServerURL/ServerPubKey and Servers may not be specified together; please use only one or the other")
+	case hasMulti:
+		merr = errors.New("mercury: Servers is not yet supported; please use ServerURL and ServerPubKey")
+		serverURLs := maps.Keys(config.Servers)
+		slices.Sort(serverURLs)
+		for _, serverURL := range serverURLs {
+			if err := validateRawServerURL(serverURL); err != nil {
+				merr = errors.Join(merr, err)
+			}
+			if len(config.Servers[serverURL]) != 32 {
+				merr = errors.Join(merr, fmt.Errorf("mercury: ServerPubKey for server %q must be a 32-byte hex string", serverURL))
+			}
+		}
+	case hasSingle:
+		if config.RawServerURL == "" {
+			merr = errors.New("mercury: ServerURL must be specified")
+		} else if err := validateRawServerURL(config.RawServerURL); err != nil {
+			merr = err
+		}
+		if len(config.ServerPubKey) != 32 {
+			merr = errors.Join(merr, errors.New("mercury: ServerPubKey is required and must be a 32-byte hex string"))
+		}
+	default:
+		merr = errors.New("mercury: either ServerURL/ServerPubKey or Servers must be specified")
+	}
+
+	return merr
+}
+
+// ValidatePluginConfigWithWarnings validates like ValidatePluginConfig, and additionally returns advisories about
+// valid but risky settings, e.g. a server URL with an IP address, that should be surfaced without blocking job creation
+func ValidatePluginConfigWithWarnings(config PluginConfig, feedID mercuryutils.FeedID) (warnings []string, err error) {
+	err = ValidatePluginConfig(config, feedID)
+
+	rawServerURLs := maps.Keys(config.Servers)
+	slices.Sort(rawServerURLs)
+	if config.RawServerURL != "" {
+		rawServerURLs = append([]string{config.RawServerURL}, rawServerURLs...)
+	}
+	for _, rawServerURL := range rawServerURLs {
+		uri, perr := parseRawServerURL(rawServerURL)
+		if perr != nil {
+			continue // already reported by ValidatePluginConfig
+		}
+		if net.ParseIP(uri.Hostname()) != nil {
+			warnings = append(warnings, fmt.Sprintf("mercury: ServerURL %q uses an IP address rather than a hostname; the server may not be reachable if its address changes", rawServerURL))
+		}
+		if port := uri.Port(); port != "" && port != "443" {
+			warnings = append(warnings, fmt.Sprintf("mercury: ServerURL %q uses non-standard port %s; make sure it is reachable through any firewalls", rawServerURL, port))
+		}
+	}
+	return warnings, err
+}
+
+// parseRawServerURL parses the server URL, assuming the wss scheme if none is specified. IPv6 literal hosts should be
+// bracketed, e.g. "[2001:db8::1]:4242"; an unbracketed literal without a port, e.g. "2001:db8::1", is bracketed rather
+// than having its last group mistaken for a port.
+func parseRawServerURL(rawServerURL string) (*url.URL, error) {
+	var normalizedURI string
+	if schemeRegexp.MatchString(rawServerURL) {
+		normalizedURI = rawServerURL
+	} else {
+		normalizedURI = fmt.Sprintf("wss://%s", rawServerURL)
+	}
+	uri, err := url.ParseRequestURI(normalizedURI)
+	if err != nil {
+		return nil, err
+	}
+	if isUnbracketedIPv6(uri.Host) {
+		uri.Host = "[" + uri.Host + "]"
+	} else if !strings.HasPrefix(uri.Host, "[") && strings.Count(uri.Host, ":") > 1 {
+		return nil, fmt.Errorf(`IPv6 literal host %q must be enclosed in brackets, e.g. "[2001:db8::1]:4242"`, uri.Host)
+	}
+	return uri, nil
+}
+
+// isUnbracketedIPv6 returns true if host is an IPv6 literal without the brackets required to add a port
+func isUnbracketedIPv6(host string) bool {
+	return strings.Contains(host, ":") && net.ParseIP(host) != nil
+}
+
+func validateRawServerURL(rawServerURL string) error {
+	uri, err := parseRawServerURL(rawServerURL)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Mercury: invalid value for ServerURL")
+	} else if uri.Scheme != "wss" {
+		return pkgerrors.Errorf(`Mercury: invalid scheme specified for MercuryServer, got: %q (scheme: %q) but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"`, rawServerURL, uri.Scheme)
+	}
+	return nil
+}
+
 var schemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
 var wssRegexp = regexp.MustCompile(`^wss://`)
 
+// ServerURL returns RawServerURL without the wss scheme, preserving any path, e.g. "example.com/mercury/v1".
+// IPv6 literal hosts are returned bracketed, e.g. "[2001:db8::1]:4242".
 func (p PluginConfig) ServerURL() string {
-	return wssRegexp.ReplaceAllString(p.RawServerURL, "")
+	serverURL := wssRegexp.ReplaceAllString(p.RawServerURL, "")
+	host, path := serverURL, ""
+	if i := strings.Index(serverURL, "/"); i >= 0 {
+		host, path = serverURL[:i], serverURL[i:]
+	}
+	if isUnbracketedIPv6(host) {
+		return "[" + host + "]" + path
+	}
+	return serverURL
+}
+
+// ServerPath returns the path component of RawServerURL, e.g. "/mercury/v1", or "" if it has none or is invalid
+func (p PluginConfig) ServerPath() string {
+	uri, err := parseRawServerURL(p.RawServerURL)
+	if err != nil {
+		return ""
+	}
+	return uri.Path
+}
+
+// CanonicalServerURL returns RawServerURL normalized so that equivalent URLs compare equal, e.g. "Host:443",
+// "wss://host" and "wss://host:443/" are all "wss://host". The scheme defaults to wss, scheme and host are lowercased,
+// the default port for the scheme is dropped and trailing slashes are trimmed from the path. Returns "" if RawServerURL
+// is invalid.
+func (p PluginConfig) CanonicalServerURL() string {
+	uri, err := parseRawServerURL(p.RawServerURL)
+	if err != nil {
+		return ""
+	}
+	scheme := strings.ToLower(uri.Scheme)
+	host := strings.ToLower(uri.Hostname())
+	if port := uri.Port(); port != "" && port != defaultPorts[scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	return scheme + "://" + host + strings.TrimRight(uri.EscapedPath(), "/")
+}
+
+// defaultPorts are the ports implied by each websocket scheme
+var defaultPorts = map[string]string{"ws": "80", "wss": "443"}
+
+// PubKeyHex returns ServerPubKey hex encoded, without a 0x prefix
+func (p PluginConfig) PubKeyHex() string {
+	return p.ServerPubKey.String()
+}
+
+// SamePubKey returns true if both configs have the same valid 32-byte ServerPubKey, compared in constant time
+func (p PluginConfig) SamePubKey(other PluginConfig) bool {
+	if len(p.ServerPubKey) != 32 || len(other.ServerPubKey) != 32 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(p.ServerPubKey, other.ServerPubKey) == 1
 }
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 var v1FeedId = [32]uint8{00, 01, 107, 74, 167, 229, 124, 167, 182, 138, 225, 191, 69, 101, 63, 86, 182, 86, 253, 58, 163, 53, 239, 127, 174, 105, 107, 102, 63, 27, 132, 114}
@@ -135,6 +138,109 @@ func Test_PluginConfig(t *testing.T) {
 	})
 }
 
+func Test_PluginConfig_Servers(t *testing.T) {
+	t.Run("with single server only", func(t *testing.T) {
+		rawToml := `
+			ServerURL = "example.com:80"
+			ServerPubKey = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		require.NoError(t, err)
+	})
+
+	t.Run("with multiple servers only", func(t *testing.T) {
+		rawToml := `
+			Servers = { "example.com:80" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", "example2.invalid:1234" = "524ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93" }
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		require.Len(t, mc.Servers, 2)
+		assert.Equal(t, "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", mc.Servers["example.com:80"].String())
+		assert.Equal(t, "524ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", mc.Servers["example2.invalid:1234"].String())
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.EqualError(t, err, "mercury: Servers is not yet supported; please use ServerURL and ServerPubKey")
+	})
+
+	t.Run("with multiple servers and invalid values", func(t *testing.T) {
+		rawToml := `
+			Servers = { "http://b.example.com" = "4242", "http://a.example.com" = "4242" }
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.EqualError(t, err, `mercury: Servers is not yet supported; please use ServerURL and ServerPubKey
+Mercury: invalid scheme specified for MercuryServer, got: "http://a.example.com" (scheme: "http") but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"
+mercury: ServerPubKey for server "http://a.example.com" must be a 32-byte hex string
+Mercury: invalid scheme specified for MercuryServer, got: "http://b.example.com" (scheme: "http") but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"
+mercury: ServerPubKey for server "http://b.example.com" must be a 32-byte hex string`)
+	})
+
+	t.Run("with only ServerPubKey", func(t *testing.T) {
+		rawToml := `
+			ServerPubKey = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		assert.EqualError(t, err, "mercury: ServerURL must be specified")
+	})
+
+	t.Run("with ServerPubKey and multiple servers", func(t *testing.T) {
+		rawToml := `
+			ServerPubKey = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+			Servers = { "example.com:80" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93" }
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		assert.EqualError(t, err, "mercury: ServerURL/ServerPubKey and Servers may not be specified together; please use only one or the other")
+	})
+
+	t.Run("with both single and multiple servers", func(t *testing.T) {
+		rawToml := `
+			ServerURL = "example.com:80"
+			ServerPubKey = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+			Servers = { "example.com:80" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93" }
+		`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mercury: ServerURL/ServerPubKey and Servers may not be specified together; please use only one or the other")
+	})
+
+	t.Run("with neither single nor multiple servers", func(t *testing.T) {
+		var mc PluginConfig
+
+		err := ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mercury: either ServerURL/ServerPubKey or Servers must be specified")
+	})
+}
+
 func Test_PluginConfig_ServerURL(t *testing.T) {
 	pc := PluginConfig{RawServerURL: "example.com"}
 	assert.Equal(t, "example.com", pc.ServerURL())
@@ -145,3 +251,185 @@ func Test_PluginConfig_ServerURL(t *testing.T) {
 	pc = PluginConfig{RawServerURL: "wss://example.com:1234/foo"}
 	assert.Equal(t, "example.com:1234/foo", pc.ServerURL())
 }
+
+func Test_PluginConfig_CanonicalServerURL(t *testing.T) {
+	for canonical, equivalents := range map[string][]string{
+		"wss://host:4242":       {"host:4242", "wss://host:4242", "wss://host:4242/", "WSS://HOST:4242", "Host:4242//"},
+		"wss://host":            {"host", "host:443", "wss://host:443/", "wss://Host/"},
+		"wss://host/mercury/v1": {"host/mercury/v1", "wss://host:443/mercury/v1/"},
+		"ws://host":             {"ws://host:80", "ws://host/"},
+		"ws://host:443":         {"ws://host:443"},
+	} {
+		for _, rawServerURL := range equivalents {
+			t.Run(rawServerURL, func(t *testing.T) {
+				assert.Equal(t, canonical, PluginConfig{RawServerURL: rawServerURL}.CanonicalServerURL())
+			})
+		}
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, "", PluginConfig{RawServerURL: "wss://host/%zz"}.CanonicalServerURL())
+	})
+}
+
+func Test_NewPluginConfig(t *testing.T) {
+	pubKey := make([]byte, 32)
+
+	t.Run("valid", func(t *testing.T) {
+		mc, err := NewPluginConfig("example.com:4242", pubKey)
+		require.NoError(t, err)
+		assert.Equal(t, "example.com:4242", mc.ServerURL())
+		assert.Equal(t, utils.PlainHexBytes(pubKey), mc.ServerPubKey)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		rawURL string
+		pubKey []byte
+		err    string
+	}{
+		{"missing URL", "", pubKey, "mercury: ServerURL must be specified"},
+		{"invalid scheme", "https://example.com", pubKey, `Mercury: invalid scheme specified for MercuryServer, got: "https://example.com" (scheme: "https")`},
+		{"invalid URL", "wss://example.com/%zz", pubKey, "Mercury: invalid value for ServerURL"},
+		{"short public key", "example.com:4242", pubKey[:31], "mercury: ServerPubKey is required and must be a 32-byte hex string"},
+		{"missing both", "", nil, "mercury: either ServerURL/ServerPubKey or Servers must be specified"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPluginConfig(tc.rawURL, tc.pubKey)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func Test_PluginConfig_IPv6ServerURL(t *testing.T) {
+	pubKey := make(utils.PlainHexBytes, 32)
+
+	for _, tc := range []struct {
+		rawServerURL string
+		serverURL    string
+		canonical    string
+	}{
+		{"[2001:db8::1]:4242", "[2001:db8::1]:4242", "wss://[2001:db8::1]:4242"},
+		{"wss://[2001:db8::1]:4242", "[2001:db8::1]:4242", "wss://[2001:db8::1]:4242"},
+		{"[2001:DB8::1]:443/mercury/", "[2001:DB8::1]:443/mercury/", "wss://[2001:db8::1]/mercury"},
+		{"2001:db8::1", "[2001:db8::1]", "wss://[2001:db8::1]"},
+		{"wss://2001:db8::1/mercury", "[2001:db8::1]/mercury", "wss://[2001:db8::1]/mercury"},
+		{"::1", "[::1]", "wss://[::1]"},
+	} {
+		t.Run(tc.rawServerURL, func(t *testing.T) {
+			mc := PluginConfig{RawServerURL: tc.rawServerURL, ServerPubKey: pubKey}
+			require.NoError(t, ValidatePluginConfig(mc, v1FeedId))
+			assert.Equal(t, tc.serverURL, mc.ServerURL())
+			assert.Equal(t, tc.canonical, mc.CanonicalServerURL())
+		})
+	}
+
+	t.Run("rejects an unbracketed IPv6 host that is not a valid literal", func(t *testing.T) {
+		mc := PluginConfig{RawServerURL: "2001:db8::zz:4242", ServerPubKey: pubKey}
+		err := ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `IPv6 literal host "2001:db8::zz:4242" must be enclosed in brackets`)
+	})
+}
+
+func Test_ValidatePluginConfigWithWarnings(t *testing.T) {
+	pubKey := "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+
+	t.Run("no warnings for a hostname on the standard port", func(t *testing.T) {
+		var mc PluginConfig
+		require.NoError(t, toml.Unmarshal([]byte(`
+			ServerURL = "wss://example.com"
+			ServerPubKey = "`+pubKey+`"
+		`), &mc))
+
+		warnings, err := ValidatePluginConfigWithWarnings(mc, v1FeedId)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("warns about an IP address and non-standard port without erroring", func(t *testing.T) {
+		var mc PluginConfig
+		require.NoError(t, toml.Unmarshal([]byte(`
+			ServerURL = "192.0.2.2:4242"
+			ServerPubKey = "`+pubKey+`"
+		`), &mc))
+
+		warnings, err := ValidatePluginConfigWithWarnings(mc, v1FeedId)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`mercury: ServerURL "192.0.2.2:4242" uses an IP address rather than a hostname; the server may not be reachable if its address changes`,
+			`mercury: ServerURL "192.0.2.2:4242" uses non-standard port 4242; make sure it is reachable through any firewalls`,
+		}, warnings)
+	})
+
+	t.Run("keeps hard failures in the error", func(t *testing.T) {
+		var mc PluginConfig
+		require.NoError(t, toml.Unmarshal([]byte(`
+			ServerURL = "192.0.2.2:4242"
+			ServerPubKey = "4242"
+		`), &mc))
+
+		warnings, err := ValidatePluginConfigWithWarnings(mc, v1FeedId)
+		require.EqualError(t, err, "mercury: ServerPubKey is required and must be a 32-byte hex string")
+		assert.Len(t, warnings, 2)
+	})
+}
+
+func Test_PluginConfig_SamePubKey(t *testing.T) {
+	key := func(s string) utils.PlainHexBytes {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+	a := PluginConfig{ServerPubKey: key("724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93")}
+	b := PluginConfig{ServerPubKey: key("524ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93")}
+
+	assert.Equal(t, "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", a.PubKeyHex())
+
+	t.Run("equal", func(t *testing.T) {
+		assert.True(t, a.SamePubKey(PluginConfig{ServerPubKey: key("724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93")}))
+	})
+	t.Run("unequal", func(t *testing.T) {
+		assert.False(t, a.SamePubKey(b))
+	})
+	t.Run("wrong length", func(t *testing.T) {
+		short := PluginConfig{ServerPubKey: key("4242")}
+		assert.False(t, short.SamePubKey(short))
+		assert.False(t, a.SamePubKey(short))
+		assert.False(t, PluginConfig{}.SamePubKey(PluginConfig{}))
+	})
+}
+
+func Test_PluginConfig_ServerPath(t *testing.T) {
+	pubKey := "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+	for _, tc := range []struct {
+		rawServerURL string
+		serverURL    string
+		path         string
+	}{
+		{"example.com", "example.com", ""},
+		{"wss://example.com:1234", "example.com:1234", ""},
+		{"wss://example.com/mercury/v1", "example.com/mercury/v1", "/mercury/v1"},
+		{"example.com:1234/mercury/v1", "example.com:1234/mercury/v1", "/mercury/v1"},
+	} {
+		t.Run(tc.rawServerURL, func(t *testing.T) {
+			var mc PluginConfig
+			require.NoError(t, toml.Unmarshal([]byte(`
+				ServerURL = "`+tc.rawServerURL+`"
+				ServerPubKey = "`+pubKey+`"
+			`), &mc))
+
+			require.NoError(t, ValidatePluginConfig(mc, v1FeedId))
+			assert.Equal(t, tc.serverURL, mc.ServerURL())
+			assert.Equal(t, tc.path, mc.ServerPath())
+		})
+	}
+
+	t.Run("still checks the scheme of URLs with a path", func(t *testing.T) {
+		mc := PluginConfig{RawServerURL: "https://example.com/mercury/v1", ServerPubKey: make(utils.PlainHexBytes, 32)}
+		err := ValidatePluginConfig(mc, v1FeedId)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `Mercury: invalid scheme specified for MercuryServer, got: "https://example.com/mercury/v1" (scheme: "https")`)
+	})
+}